@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+)
+
+type AcceptContentCommand struct {
+	*BaseCommand
+}
+
+func (mc *AcceptContentCommand) Arguments() []ArgumentContext {
+	return []ArgumentContext{
+		{
+			Name:    "id",
+			Usage:   "The ID of the URL whose current content becomes the new baseline.",
+			Type:    enums.Int,
+			Default: 0,
+		},
+	}
+}
+
+func (mc *AcceptContentCommand) Flags() []FlagContext {
+	return []FlagContext{}
+}
+
+func (mc *AcceptContentCommand) Action(ctx context.Context, cmd CommandContext) error {
+	id := cmd.Int("id")
+
+	// Check if required argument is provided
+	if id == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	pool := InitiateDB(ctx, mc.Log)
+	defer pool.Close()
+	urlRepository := database.NewUrlRepository(pool)
+
+	url, err := urlRepository.FindById(ctx, id)
+	if err != nil {
+		fmt.Printf("Error finding url: %v", err)
+		return err
+	}
+
+	if !url.Options.DetectContentChanges {
+		return fmt.Errorf("content change detection is not enabled for url %v", id)
+	}
+
+	hash, err := database.NewUrlStatusRepository(pool).GetLastContentHash(ctx, url.Id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("url %v has not been checked yet", id)
+		}
+		fmt.Printf("Error fetching current content: %v", err)
+		return err
+	}
+
+	err = urlRepository.UpdateContentBaseline(ctx, url.Id, hash)
+	if err != nil {
+		fmt.Printf("Error accepting content: %v", err)
+		return err
+	}
+
+	fmt.Printf("Content baseline updated, ID: %v", id)
+	return nil
+}
+
+func NewAcceptContentCommand(logger *slog.Logger) *AcceptContentCommand {
+	return &AcceptContentCommand{
+		BaseCommand: &BaseCommand{
+			name:    "accept_content",
+			aliases: []string{"accept"},
+			usage:   "Accept the current content of a URL as its new baseline for change detection.",
+			Log:     logger,
+		},
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/horlerdipo/watchdog/core"
 	"github.com/horlerdipo/watchdog/database"
 	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/env"
+	"github.com/horlerdipo/watchdog/heartbeat"
 	"github.com/jackc/pgx/v5"
 	"log/slog"
+	"strings"
 	"time"
 )
 
@@ -62,28 +66,127 @@ func Analysis(ctx context.Context, logger *slog.Logger, urlId int) {
 
 	//check the last downtime and subtract if from now
 	//and if there is no downtime, subtract it from the created at time
-	recentDownTime = getRecentDownTime(ctx, &url, urlStatusRepository, logger)
+	recentDownTime = getRecentDownTime(ctx, &url, urlStatusRepository, incidentRepository, logger)
 	lastCheckStatus, err := urlStatusRepository.GetLastStatus(ctx, url.Id)
 	if err != nil {
 		logger.Error("Unable to fetch last check status: "+err.Error(), url.Id)
 	}
 
-	fmt.Printf("URL: %s\n", url.Url)
+	fmt.Printf("URL: %s\n", url.DisplayUrl())
+	fmt.Printf("Monitor Type: %s\n", url.Type)
+	if url.Type == enums.Heartbeat {
+		fmt.Printf("Ping URL: %s\n", heartbeat.PingUrl(env.FetchString("HEARTBEAT_BASE_URL", "http://localhost:8080"), url.HeartbeatToken))
+	}
+	if url.Type == enums.Dns {
+		fmt.Printf("DNS Record Type: %s\n", url.Options.RecordType)
+		if len(url.Options.ExpectedAnswers) > 0 {
+			fmt.Printf("Expected Answers: %s\n", strings.Join(url.Options.ExpectedAnswers, ", "))
+		}
+	}
+	if url.Type == enums.Exec && len(url.Options.Args) > 0 {
+		fmt.Printf("Arguments: %s\n", strings.Join(url.Options.Args, " "))
+	}
+	if url.Options.Query != "" {
+		fmt.Printf("Query: %s\n", url.Options.Query)
+	}
+	if url.Options.MaxReplicationLagSeconds > 0 {
+		fmt.Printf("Max Replication Lag: %ds\n", url.Options.MaxReplicationLagSeconds)
+	}
 	fmt.Printf("HTTP Method: %s\n", url.HttpMethod)
+	if url.Type == enums.Http && url.Options.RedirectPolicy != "" {
+		fmt.Printf("Redirect Policy: %s\n", url.Options.RedirectPolicy)
+	}
+	if url.Options.DetectContentChanges {
+		switch {
+		case url.ContentHash == "":
+			fmt.Println("Content Baseline: not recorded yet")
+		case lastCheckStatus.ContentHash != "" && lastCheckStatus.ContentHash != url.ContentHash:
+			fmt.Printf("Content Baseline: %s (changed, last check returned %s)\n", url.ContentHash[:12], lastCheckStatus.ContentHash[:12])
+		default:
+			fmt.Printf("Content Baseline: %s (unchanged)\n", url.ContentHash[:12])
+		}
+	}
 	fmt.Printf("Monitoring Frequency: %s\n", url.MonitoringFrequency)
+	fmt.Printf("Expected Status Codes: %s\n", url.ExpectedStatusCodes)
+	if url.Proxy == core.DirectProxy {
+		fmt.Println("Proxy: direct")
+	} else if url.Proxy != "" {
+		if proxyUrl, err := core.ParseProxyUrl(url.Proxy); err == nil {
+			fmt.Printf("Proxy: %s\n", proxyUrl.Redacted())
+		}
+	}
+	if url.TLS.ClientCertFile != "" {
+		fmt.Printf("Client Certificate: %s\n", url.TLS.ClientCertFile)
+	}
+	if url.TLS.CaBundleFile != "" {
+		fmt.Printf("CA Bundle: %s\n", url.TLS.CaBundleFile)
+	}
+	if url.TLS.InsecureSkipVerify {
+		fmt.Println("TLS Verification: disabled")
+	}
+	if url.LatencyThresholdMs > 0 {
+		fmt.Printf("Latency Threshold: %dms\n", url.LatencyThresholdMs)
+	}
+	if url.FailureThreshold > 0 {
+		fmt.Printf("Failure Threshold: %d consecutive checks\n", url.FailureThreshold)
+	}
+	if url.RecoveryThreshold > 0 {
+		fmt.Printf("Recovery Threshold: %d consecutive checks\n", url.RecoveryThreshold)
+	}
+	for _, assertion := range url.Assertions {
+		fmt.Printf("Assertion: %s\n", assertion)
+	}
 	fmt.Printf("Site Status: %s\n", url.Status)
+	if url.FlappingSince != nil {
+		fmt.Printf("Flapping Since: %v (alerts suppressed)\n", url.FlappingSince.Format(time.RFC1123))
+	}
 	switch url.Status {
-	case enums.Healthy:
+	case enums.Healthy, enums.Degraded:
 		fmt.Printf("Currently Up for: %v \n", recentDownTime)
 	case enums.UnHealthy:
 		fmt.Printf("Currently Down for: %v \n", recentDownTime)
 	case enums.Pending:
 		fmt.Println("No check has been performed yet.")
+	case enums.Paused:
+		fmt.Println("Monitoring is paused, no checks are performed.")
+	case enums.Maintenance:
+		fmt.Println("In maintenance, checks are recorded without changing the status or alerting.")
+	}
+
+	if url.CertificateExpiresAt != nil {
+		daysRemaining := int(url.CertificateExpiresAt.Sub(timeNow).Hours() / 24)
+		fmt.Printf("Certificate Expires: %v (%d days)\n", url.CertificateExpiresAt.Format(time.RFC1123), daysRemaining)
 	}
 
 	if lastCheckStatus.UrlId != 0 {
 		lastCheckTime = timeNow.Sub(lastCheckStatus.Time)
 		fmt.Printf("Last Checked: %v ago\n", (lastCheckTime.Abs()).Round(time.Second))
+		fmt.Printf("Last Check Result: %s\n", lastCheckStatus.Status)
+		fmt.Printf("Last Response Time: %dms\n", lastCheckStatus.LatencyMs)
+		for _, step := range lastCheckStatus.Steps {
+			outcome := "passed"
+			if !step.Success {
+				outcome = "failed"
+			}
+			fmt.Printf("  Step %s: %s in %dms\n", step.Name, outcome, step.LatencyMs)
+		}
+		if lastCheckStatus.Output != "" {
+			fmt.Printf("Last Output: %s\n", lastCheckStatus.Output)
+		}
+		if lastCheckStatus.PerfData != "" {
+			fmt.Printf("Last Perfdata: %s\n", lastCheckStatus.PerfData)
+		}
+		if len(lastCheckStatus.Redirects) > 0 {
+			fmt.Printf("Last Redirect Chain: %s\n", strings.Join(lastCheckStatus.Redirects, " -> "))
+		}
+	}
+
+	lastFailure, err := urlStatusRepository.GetRecentStatus(ctx, url.Id, enums.UnHealthy, enums.Unknown)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		logger.Error("Unable to fetch last failure", "error", err, "url_id", url.Id)
+	}
+	if lastFailure.FailureCode != "" {
+		fmt.Printf("Last Failure: %s: %s (%v ago)\n", lastFailure.FailureCode, lastFailure.FailureReason, timeNow.Sub(lastFailure.Time).Round(time.Second))
 	}
 
 	periods := []int{1, 7, 30, 365}
@@ -106,23 +209,29 @@ func Analysis(ctx context.Context, logger *slog.Logger, urlId int) {
 	}
 }
 
-func getRecentDownTime(ctx context.Context, url *database.Url, urlStatusRepository database.UrlStatusRepository, logger *slog.Logger) time.Duration {
+func getRecentDownTime(ctx context.Context, url *database.Url, urlStatusRepository database.UrlStatusRepository, incidentRepository database.IncidentRepository, logger *slog.Logger) time.Duration {
 	timeNow := time.Now()
-	var recentDownTimeUrlStatus database.UrlStatus
-	var err error
 
-	if url.Status == enums.Healthy {
-		recentDownTimeUrlStatus, err = urlStatusRepository.GetRecentStatus(ctx, url.Id, false)
-	} else {
-		recentDownTimeUrlStatus, err = urlStatusRepository.GetRecentStatus(ctx, url.Id, true)
+	//single failed checks are recorded too, so a site is up since its last outage was resolved
+	if url.Status.IsUp() {
+		incident, err := incidentRepository.GetLatest(ctx, url.Id)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("Unable to fetch most recent incident", "error", err, "url_id", url.Id)
+		}
+		if incident.ResolvedAt.IsZero() {
+			return timeNow.Sub(url.CreatedAt)
+		}
+		return timeNow.Sub(incident.ResolvedAt)
 	}
+
+	recentUpTimeUrlStatus, err := urlStatusRepository.GetRecentStatus(ctx, url.Id, enums.Healthy, enums.Degraded)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		logger.Error("Unable to fetch most recent downtime: "+err.Error(), url.Id)
+		logger.Error("Unable to fetch most recent uptime", "error", err, "url_id", url.Id)
 	}
 
-	if recentDownTimeUrlStatus.UrlId == 0 {
+	if recentUpTimeUrlStatus.UrlId == 0 {
 		return timeNow.Sub(url.CreatedAt)
 	} else {
-		return timeNow.Sub(recentDownTimeUrlStatus.Time)
+		return timeNow.Sub(recentUpTimeUrlStatus.Time)
 	}
 }
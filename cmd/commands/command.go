@@ -35,6 +35,10 @@ func (cc *CommandContainer) RegisterAll(logger *slog.Logger) {
 	cc.Register(NewRemoveCommand(logger))
 	cc.Register(NewListCommand(logger))
 	cc.Register(NewAnalysisCommand(logger))
+	cc.Register(NewAcceptContentCommand(logger))
+	cc.Register(NewPauseCommand(logger))
+	cc.Register(NewMaintenanceCommand(logger))
+	cc.Register(NewResumeCommand(logger))
 }
 
 func (cc *CommandContainer) Initiate(logger *slog.Logger) []*cli.Command {
@@ -68,6 +72,18 @@ func (cc *CommandContainer) Initiate(logger *slog.Logger) []*cli.Command {
 					Usage: flag.Usage,
 					Value: flag.Default.(int),
 				}
+			} else if flag.Type == enums.Bool {
+				transformedFlag = &cli.BoolFlag{
+					Name:  flag.Name,
+					Usage: flag.Usage,
+					Value: flag.Default.(bool),
+				}
+			} else if flag.Type == enums.StringSlice {
+				transformedFlag = &cli.StringSliceFlag{
+					Name:  flag.Name,
+					Usage: flag.Usage,
+					Value: flag.Default.([]string),
+				}
 			} else {
 				transformedFlag = &cli.StringFlag{
 					Name:  flag.Name,
@@ -100,6 +116,7 @@ type CommandContext interface {
 	BoolFlag(name string) bool
 	IntFlag(name string) int
 	StringFlag(name string) string
+	StringSliceFlag(name string) []string
 }
 
 type ArgumentContext struct {
@@ -139,6 +156,10 @@ func (u *UrfaveContext) StringFlag(name string) string {
 	return u.cmd.String(name)
 }
 
+func (u *UrfaveContext) StringSliceFlag(name string) []string {
+	return u.cmd.StringSlice(name)
+}
+
 func (u *UrfaveContext) IntFlag(name string) int {
 	return u.cmd.Int(name)
 }
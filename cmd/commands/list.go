@@ -27,6 +27,12 @@ func (mc *ListCommand) Flags() []FlagContext {
 			Default: 20,
 			Type:    enums.Int,
 		},
+		{
+			Name:    "type",
+			Usage:   "Filter results by monitor type",
+			Default: "",
+			Type:    enums.String,
+		},
 		{
 			Name:    "http_method",
 			Usage:   "Filter results by http method",
@@ -41,7 +47,7 @@ func (mc *ListCommand) Flags() []FlagContext {
 		},
 		{
 			Name:    "status",
-			Usage:   "Filter results by status",
+			Usage:   "Filter results by status: pending, healthy, degraded, unhealthy, paused or maintenance",
 			Default: "",
 			Type:    enums.String,
 		},
@@ -51,6 +57,7 @@ func (mc *ListCommand) Flags() []FlagContext {
 func (mc *ListCommand) Action(ctx context.Context, cmd CommandContext) error {
 	page := cmd.IntFlag("page")
 	perPage := cmd.IntFlag("per_page")
+	monitorType := cmd.StringFlag("type")
 	httpMethod := cmd.StringFlag("http_method")
 	frequency := cmd.StringFlag("frequency")
 	status := cmd.StringFlag("status")
@@ -65,6 +72,15 @@ func (mc *ListCommand) Action(ctx context.Context, cmd CommandContext) error {
 	offset := (page - 1) * perPage
 
 	filter := database.UrlQueryFilter{}
+	if monitorType != "" {
+		parsedMonitorType, err := enums.ParseMonitorType(monitorType)
+		if err != nil {
+			fmt.Printf("failed to fetch URLs: %v", err)
+			return err
+		}
+		filter.Type = parsedMonitorType
+	}
+
 	if httpMethod != "" {
 		parsedHttpMethod, err := enums.ParseHttpMethod(httpMethod)
 		if err != nil {
@@ -139,13 +155,17 @@ func DisplayUrls(urls []database.Url, page int, offset int, hasPrevious bool, ha
 	fmt.Println("\n" + strings.Repeat("-", 60))
 
 	for i, url := range urls {
-		fmt.Printf("%d. %s\n", offset+i+1, url.Url)
-		fmt.Printf("   ID: %v | Method: %s | Status: %s | Frequency: %s\n",
+		fmt.Printf("%d. %s\n", offset+i+1, url.DisplayUrl())
+		fmt.Printf("   ID: %v | Type: %s | Method: %s | Status: %s | Frequency: %s\n",
 			url.Id,
+			url.Type.ToString(),
 			url.HttpMethod.ToString(),
 			url.Status.ToString(),
 			url.MonitoringFrequency.ToString())
 		fmt.Printf("   Contact: %s\n", url.ContactEmail)
+		if url.TLS.InsecureSkipVerify {
+			fmt.Println("   ⚠ TLS certificate verification is disabled")
+		}
 		fmt.Println()
 	}
 
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"github.com/horlerdipo/watchdog/enums"
+	"log/slog"
+)
+
+type MaintenanceCommand struct {
+	*BaseCommand
+}
+
+func (mc *MaintenanceCommand) Arguments() []ArgumentContext {
+	return []ArgumentContext{
+		{
+			Name:    "id",
+			Usage:   "The ID of the URL going into maintenance.",
+			Type:    enums.Int,
+			Default: 0,
+		},
+	}
+}
+
+func (mc *MaintenanceCommand) Flags() []FlagContext {
+	return []FlagContext{}
+}
+
+func (mc *MaintenanceCommand) Action(ctx context.Context, cmd CommandContext) error {
+	return changeStatus(ctx, mc.Log, cmd.Int("id"), enums.Maintenance)
+}
+
+func NewMaintenanceCommand(logger *slog.Logger) *MaintenanceCommand {
+	return &MaintenanceCommand{
+		BaseCommand: &BaseCommand{
+			name:    "maintenance",
+			aliases: []string{"mt"},
+			usage:   "Keep checking a URL without changing its status or alerting until it is resumed.",
+			Log:     logger,
+		},
+	}
+}
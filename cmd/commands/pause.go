@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/horlerdipo/watchdog/events/listeners"
+	"log/slog"
+)
+
+type PauseCommand struct {
+	*BaseCommand
+}
+
+func (mc *PauseCommand) Arguments() []ArgumentContext {
+	return []ArgumentContext{
+		{
+			Name:    "id",
+			Usage:   "The ID of the URL to stop checking.",
+			Type:    enums.Int,
+			Default: 0,
+		},
+	}
+}
+
+func (mc *PauseCommand) Flags() []FlagContext {
+	return []FlagContext{}
+}
+
+func (mc *PauseCommand) Action(ctx context.Context, cmd CommandContext) error {
+	return changeStatus(ctx, mc.Log, cmd.Int("id"), enums.Paused)
+}
+
+func NewPauseCommand(logger *slog.Logger) *PauseCommand {
+	return &PauseCommand{
+		BaseCommand: &BaseCommand{
+			name:    "pause",
+			aliases: []string{},
+			usage:   "Stop checking a URL until it is resumed.",
+			Log:     logger,
+		},
+	}
+}
+
+// changeStatus moves a URL into or out of the paused and maintenance states, which only the owner
+// can set. Workers read the status from the monitor details in Redis, so the url's entry is refreshed too,
+// and the transition is announced like the ones the supervisor makes.
+func changeStatus(ctx context.Context, logger *slog.Logger, id int, status enums.SiteHealth) error {
+	// Check if required argument is provided
+	if id == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	pool := InitiateDB(ctx, logger)
+	defer pool.Close()
+	urlRepository := database.NewUrlRepository(pool)
+
+	url, err := urlRepository.FindById(ctx, id)
+	if err != nil {
+		fmt.Printf("Error finding url: %v", err)
+		return err
+	}
+
+	if url.Status == status {
+		return fmt.Errorf("url %v is already %v", id, status)
+	}
+	if !status.IsManual() && !url.Status.IsManual() {
+		return fmt.Errorf("url %v is neither paused nor in maintenance", id)
+	}
+
+	err = urlRepository.UpdateStatus(ctx, url.Id, status)
+	if err != nil {
+		fmt.Printf("Error updating status: %v", err)
+		return err
+	}
+
+	//the supervisor reloads the status once it sees the new status_changed_at in the details
+	from := url.Status
+	url, err = urlRepository.FindById(ctx, id)
+	if err != nil {
+		fmt.Printf("Error finding url: %v", err)
+		return err
+	}
+	redisClient := InitiateRedis(ctx, logger)
+	err = redisClient.HSet(ctx, core.FormatRedisHash(url.MonitoringFrequency.ToSeconds()), url.Id, url).Err()
+	if err != nil {
+		fmt.Printf("Error refreshing url in redis: %v", err)
+		return err
+	}
+
+	eventBus := core.NewEventBus(logger)
+	manualListener := listeners.NewMonitorManualListener(ctx, logger, pool)
+	eventBus.Subscribe("monitor.paused", manualListener)
+	eventBus.Subscribe("monitor.maintenance", manualListener)
+	eventBus.Subscribe("monitor.resumed", manualListener)
+	eventBus.Dispatch(&events.StatusChanged{
+		UrlId: url.Id,
+		Url:   url.Url,
+		From:  from,
+		To:    status,
+	})
+	eventBus.Wait()
+
+	fmt.Printf("URL status changed from %v to %v, ID: %v", from, status, id)
+	return nil
+}
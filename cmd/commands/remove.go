@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"github.com/horlerdipo/watchdog/core"
 	"github.com/horlerdipo/watchdog/database"
 	"github.com/horlerdipo/watchdog/enums"
 	"log/slog"
@@ -58,6 +59,10 @@ func (mc *RemoveCommand) Action(ctx context.Context, cmd CommandContext) error {
 		return err
 	}
 
+	if url.Type == enums.Heartbeat {
+		redisClient.Del(ctx, core.FormatRedisHeartbeat(url.Id))
+	}
+
 	fmt.Printf("URL successfully removing, ID: %v", id)
 	return nil
 }
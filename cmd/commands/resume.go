@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"github.com/horlerdipo/watchdog/enums"
+	"log/slog"
+)
+
+type ResumeCommand struct {
+	*BaseCommand
+}
+
+func (mc *ResumeCommand) Arguments() []ArgumentContext {
+	return []ArgumentContext{
+		{
+			Name:    "id",
+			Usage:   "The ID of the paused or maintenance URL to resume.",
+			Type:    enums.Int,
+			Default: 0,
+		},
+	}
+}
+
+func (mc *ResumeCommand) Flags() []FlagContext {
+	return []FlagContext{}
+}
+
+func (mc *ResumeCommand) Action(ctx context.Context, cmd CommandContext) error {
+	return changeStatus(ctx, mc.Log, cmd.Int("id"), enums.Pending)
+}
+
+func NewResumeCommand(logger *slog.Logger) *ResumeCommand {
+	return &ResumeCommand{
+		BaseCommand: &BaseCommand{
+			name:    "resume",
+			aliases: []string{},
+			usage:   "Resume normal monitoring of a paused or maintenance URL.",
+			Log:     logger,
+		},
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/horlerdipo/watchdog/enums"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Assertion is a check run against the response body after a request completes.
+// Path and Operator are only used by json assertions.
+type Assertion struct {
+	Type     enums.AssertionType     `json:"type"`
+	Value    string                  `json:"value"`
+	Path     string                  `json:"path,omitempty"`
+	Operator enums.AssertionOperator `json:"operator,omitempty"`
+}
+
+func NewAssertion(assertionType enums.AssertionType, value string) (Assertion, error) {
+	assertion := Assertion{Type: assertionType, Value: value}
+	return assertion, assertion.Validate()
+}
+
+// ParseJsonAssertion parses expressions of the form `<path> <operator> <value>`,
+// e.g. `$.db == "ok"`, `$.queue in ok,degraded` or `$.latency_ms < 250`.
+func ParseJsonAssertion(expression string) (Assertion, error) {
+	fields := strings.Fields(expression)
+	if len(fields) < 3 {
+		return Assertion{}, fmt.Errorf("invalid json assertion %q, expected `<path> <operator> <value>`", expression)
+	}
+
+	operator, err := enums.ParseAssertionOperator(fields[1])
+	if err != nil {
+		return Assertion{}, err
+	}
+
+	assertion := Assertion{
+		Type:     enums.Json,
+		Path:     fields[0],
+		Operator: operator,
+		Value:    strings.Join(fields[2:], " "),
+	}
+	return assertion, assertion.Validate()
+}
+
+func (a Assertion) Validate() error {
+	if a.Value == "" {
+		return fmt.Errorf("%s assertion requires a value", a.Type)
+	}
+
+	switch a.Type {
+	case enums.Contains, enums.NotContains:
+		return nil
+	case enums.Regex:
+		_, err := regexp.Compile(a.Value)
+		if err != nil {
+			return fmt.Errorf("invalid regex assertion %q: %w", a.Value, err)
+		}
+		return nil
+	case enums.Json:
+		if _, err := splitJsonPath(a.Path); err != nil {
+			return err
+		}
+		if _, err := enums.ParseAssertionOperator(a.Operator.ToString()); err != nil {
+			return err
+		}
+		if a.Operator.IsNumeric() {
+			if _, err := strconv.ParseFloat(unquoteJsonValue(a.Value), 64); err != nil {
+				return fmt.Errorf("json assertion operator %s requires a numeric value, got %s", a.Operator, a.Value)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid assertion type: %s", a.Type)
+	}
+}
+
+// Evaluate returns true when the body satisfies the assertion.
+func (a Assertion) Evaluate(body []byte) (bool, error) {
+	switch a.Type {
+	case enums.Contains:
+		return bytes.Contains(body, []byte(a.Value)), nil
+	case enums.NotContains:
+		return !bytes.Contains(body, []byte(a.Value)), nil
+	case enums.Regex:
+		expression, err := regexp.Compile(a.Value)
+		if err != nil {
+			return false, err
+		}
+		return expression.Match(body), nil
+	case enums.Json:
+		return a.evaluateJson(body)
+	default:
+		return false, fmt.Errorf("invalid assertion type: %s", a.Type)
+	}
+}
+
+func (a Assertion) evaluateJson(body []byte) (bool, error) {
+	resolved, err := LookupJsonPath(body, a.Path)
+	if err != nil {
+		return false, err
+	}
+	actual := jsonValueToString(resolved)
+
+	switch a.Operator {
+	case enums.Equals:
+		return jsonValuesEqual(actual, unquoteJsonValue(a.Value)), nil
+	case enums.NotEquals:
+		return !jsonValuesEqual(actual, unquoteJsonValue(a.Value)), nil
+	case enums.In:
+		for _, candidate := range strings.Split(a.Value, ",") {
+			if jsonValuesEqual(actual, unquoteJsonValue(candidate)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case enums.GreaterThan, enums.GreaterThanOrEqual, enums.LessThan, enums.LessThanOrEqual:
+		actualNumber, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return false, fmt.Errorf("json path %s is not a number: %s", a.Path, actual)
+		}
+		expectedNumber, err := strconv.ParseFloat(unquoteJsonValue(a.Value), 64)
+		if err != nil {
+			return false, err
+		}
+		switch a.Operator {
+		case enums.GreaterThan:
+			return actualNumber > expectedNumber, nil
+		case enums.GreaterThanOrEqual:
+			return actualNumber >= expectedNumber, nil
+		case enums.LessThan:
+			return actualNumber < expectedNumber, nil
+		default:
+			return actualNumber <= expectedNumber, nil
+		}
+	default:
+		return false, fmt.Errorf("invalid assertion operator: %s", a.Operator)
+	}
+}
+
+func jsonValuesEqual(actual string, expected string) bool {
+	if actual == expected {
+		return true
+	}
+	actualNumber, err := strconv.ParseFloat(actual, 64)
+	if err != nil {
+		return false
+	}
+	expectedNumber, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+	return actualNumber == expectedNumber
+}
+
+func (a Assertion) String() string {
+	switch a.Type {
+	case enums.Contains:
+		return fmt.Sprintf("body must contain %q", a.Value)
+	case enums.NotContains:
+		return fmt.Sprintf("body must not contain %q", a.Value)
+	case enums.Regex:
+		return fmt.Sprintf("body must match /%s/", a.Value)
+	case enums.Json:
+		return fmt.Sprintf("json %s %s %s", a.Path, a.Operator, a.Value)
+	default:
+		return fmt.Sprintf("%s %q", a.Type, a.Value)
+	}
+}
+
+// EvaluateAssertions returns the first assertion the body fails, if any.
+func EvaluateAssertions(assertions []Assertion, body []byte) (*Assertion, error) {
+	for _, assertion := range assertions {
+		ok, err := assertion.Evaluate(body)
+		if err != nil {
+			return &assertion, err
+		}
+		if !ok {
+			return &assertion, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/horlerdipo/watchdog/enums"
+	"strings"
+)
+
+// AuthConfig holds the credentials a monitor uses to authenticate its checks.
+// Only the fields relevant to Type are set.
+type AuthConfig struct {
+	Type         enums.AuthType `json:"type,omitempty"`
+	Username     string         `json:"username,omitempty"`
+	Password     string         `json:"password,omitempty"`
+	Token        string         `json:"token,omitempty"`
+	TokenUrl     string         `json:"token_url,omitempty"`
+	ClientId     string         `json:"client_id,omitempty"`
+	ClientSecret string         `json:"client_secret,omitempty"`
+	Scopes       []string       `json:"scopes,omitempty"`
+}
+
+func (ac AuthConfig) Enabled() bool {
+	return ac.Type != "" && ac.Type != enums.NoAuth
+}
+
+func (ac AuthConfig) Validate() error {
+	switch ac.Type {
+	case "", enums.NoAuth:
+		return nil
+	case enums.BasicAuth:
+		if ac.Username == "" {
+			return fmt.Errorf("basic auth requires a username")
+		}
+		return nil
+	case enums.BearerAuth:
+		if ac.Token == "" {
+			return fmt.Errorf("bearer auth requires a token")
+		}
+		return nil
+	case enums.OAuth2:
+		if ac.TokenUrl == "" || ac.ClientId == "" || ac.ClientSecret == "" {
+			return fmt.Errorf("oauth2 auth requires a token url, client id and client secret")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid auth type: %s", ac.Type)
+	}
+}
+
+// CacheKey identifies the OAuth2 token this configuration resolves to. The secret is part of it,
+// hashed, so rotating it fetches a new token.
+func (ac AuthConfig) CacheKey() string {
+	secret := sha256.Sum256([]byte(ac.ClientSecret))
+	return strings.Join([]string{ac.TokenUrl, ac.ClientId, hex.EncodeToString(secret[:]), strings.Join(ac.Scopes, " ")}, "|")
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"regexp"
+	"strings"
+)
+
+// ContentExclusions lists the dynamic parts of a page ignored when hashing its content.
+type ContentExclusions struct {
+	Selectors []string `json:"selectors,omitempty"`
+	Patterns  []string `json:"patterns,omitempty"`
+}
+
+func (ce ContentExclusions) Validate() error {
+	for _, selector := range ce.Selectors {
+		if _, err := cascadia.Compile(selector); err != nil {
+			return fmt.Errorf("invalid css selector %q: %w", selector, err)
+		}
+	}
+	for _, pattern := range ce.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid exclusion pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// HashContent returns the sha256 of body after removing the excluded elements and
+// patterns and collapsing whitespace, including whitespace between tags, so cosmetic
+// differences do not change the hash.
+func HashContent(body []byte, exclusions ContentExclusions) (string, error) {
+	content := string(body)
+
+	if len(exclusions.Selectors) > 0 {
+		document, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return "", fmt.Errorf("unable to parse html: %w", err)
+		}
+		for _, selector := range exclusions.Selectors {
+			document.Find(selector).Remove()
+		}
+		content, err = goquery.OuterHtml(document.Selection)
+		if err != nil {
+			return "", fmt.Errorf("unable to render html: %w", err)
+		}
+	}
+
+	for _, pattern := range exclusions.Patterns {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid exclusion pattern %q: %w", pattern, err)
+		}
+		content = expression.ReplaceAllString(content, "")
+	}
+
+	content = strings.Join(strings.Fields(content), " ")
+	content = strings.ReplaceAll(content, "> <", "><")
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:]), nil
+}
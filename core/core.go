@@ -9,3 +9,7 @@ func FormatRedisList(interval int) string {
 func FormatRedisHash(interval int) string {
 	return fmt.Sprintf("urls_details:%v", interval)
 }
+
+func FormatRedisHeartbeat(urlId int) string {
+	return fmt.Sprintf("heartbeats:%v", urlId)
+}
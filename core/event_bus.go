@@ -10,6 +10,13 @@ type Event interface {
 	Name() string
 }
 
+// OrderedEvent is implemented by events that must be handled after every earlier event with the
+// same ordering key, e.g. everything that happens to one URL. Handlers of other keys still run concurrently.
+type OrderedEvent interface {
+	Event
+	OrderingKey() string
+}
+
 type EventHandler interface {
 	Handle(event Event)
 }
@@ -18,12 +25,24 @@ type EventBus interface {
 	Logger() *slog.Logger
 	Subscribe(eventName string, handler EventHandler)
 	Dispatch(event Event)
+	//Wait blocks until every event dispatched so far has been handled
+	Wait()
 }
 
 type EventBusImpl struct {
-	handlers map[string][]EventHandler
-	rwMutex  sync.RWMutex
-	Log      *slog.Logger
+	handlers    map[string][]EventHandler
+	rwMutex     sync.RWMutex
+	queues      map[string]*eventQueue
+	queuesMutex sync.Mutex
+	pending     sync.WaitGroup
+	Log         *slog.Logger
+}
+
+// eventQueue holds the pending events of one ordering key, drained by at most one goroutine at a time
+type eventQueue struct {
+	mutex    sync.Mutex
+	events   []Event
+	draining bool
 }
 
 func (bus *EventBusImpl) Logger() *slog.Logger {
@@ -37,17 +56,75 @@ func (bus *EventBusImpl) Subscribe(eventName string, handler EventHandler) {
 }
 
 func (bus *EventBusImpl) Dispatch(event Event) {
+	if ordered, ok := event.(OrderedEvent); ok {
+		bus.enqueue(ordered)
+		return
+	}
+
 	bus.rwMutex.RLock()
 	defer bus.rwMutex.RUnlock()
 	for _, handler := range bus.handlers[event.Name()] {
 		bus.Logger().Info(fmt.Sprintf("Dispatching %s event to listeners", event.Name()))
-		go handler.Handle(event)
+		bus.pending.Add(1)
+		go func() {
+			defer bus.pending.Done()
+			handler.Handle(event)
+		}()
+	}
+}
+
+func (bus *EventBusImpl) Wait() {
+	bus.pending.Wait()
+}
+
+// enqueue appends the event to the queue of its key. The queue is drained in its own goroutine, so
+// handlers may dispatch further events without blocking, but never run in parallel for the same key.
+func (bus *EventBusImpl) enqueue(event OrderedEvent) {
+	bus.queuesMutex.Lock()
+	queue, ok := bus.queues[event.OrderingKey()]
+	if !ok {
+		queue = &eventQueue{}
+		bus.queues[event.OrderingKey()] = queue
+	}
+	bus.queuesMutex.Unlock()
+
+	bus.pending.Add(1)
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.events = append(queue.events, event)
+	if !queue.draining {
+		queue.draining = true
+		go bus.drain(queue)
+	}
+}
+
+func (bus *EventBusImpl) drain(queue *eventQueue) {
+	for {
+		queue.mutex.Lock()
+		if len(queue.events) == 0 {
+			queue.draining = false
+			queue.mutex.Unlock()
+			return
+		}
+		event := queue.events[0]
+		queue.events = queue.events[1:]
+		queue.mutex.Unlock()
+
+		bus.rwMutex.RLock()
+		handlers := bus.handlers[event.Name()]
+		bus.rwMutex.RUnlock()
+		for _, handler := range handlers {
+			bus.Logger().Info(fmt.Sprintf("Dispatching %s event to listeners", event.Name()))
+			handler.Handle(event)
+		}
+		bus.pending.Done()
 	}
 }
 
 func NewEventBus(log *slog.Logger) EventBus {
 	return &EventBusImpl{
 		handlers: make(map[string][]EventHandler),
+		queues:   make(map[string]*eventQueue),
 		Log:      log,
 	}
 }
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// Heartbeat is the latest check-in state of a push monitor, kept in Redis.
+type Heartbeat struct {
+	LastPingAt   time.Time
+	StartedAt    time.Time
+	LastDuration time.Duration
+	Failed       bool
+}
+
+func NewHeartbeatToken() (string, error) {
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func FetchHeartbeat(ctx context.Context, redisClient *redis.Client, urlId int) (Heartbeat, error) {
+	fields, err := redisClient.HGetAll(ctx, FormatRedisHeartbeat(urlId)).Result()
+	if err != nil {
+		return Heartbeat{}, err
+	}
+
+	var heartbeat Heartbeat
+	if value, ok := fields["last_ping_at"]; ok {
+		heartbeat.LastPingAt = parseUnixMilli(value)
+	}
+	if value, ok := fields["started_at"]; ok {
+		heartbeat.StartedAt = parseUnixMilli(value)
+	}
+	if value, ok := fields["last_duration_ms"]; ok {
+		durationMs, _ := strconv.ParseInt(value, 10, 64)
+		heartbeat.LastDuration = time.Duration(durationMs) * time.Millisecond
+	}
+	heartbeat.Failed = fields["failed"] == "1"
+	return heartbeat, nil
+}
+
+// StartHeartbeat records that a job has started, so its duration can be measured on completion.
+func StartHeartbeat(ctx context.Context, redisClient *redis.Client, urlId int) error {
+	return redisClient.HSet(ctx, FormatRedisHeartbeat(urlId), "started_at", time.Now().UnixMilli()).Err()
+}
+
+// RecordHeartbeat records a check-in, and whether the job reported itself as failed.
+func RecordHeartbeat(ctx context.Context, redisClient *redis.Client, urlId int, failed bool) error {
+	key := FormatRedisHeartbeat(urlId)
+	now := time.Now()
+
+	heartbeat, err := FetchHeartbeat(ctx, redisClient, urlId)
+	if err != nil {
+		return err
+	}
+
+	var durationMs int64
+	if !heartbeat.StartedAt.IsZero() {
+		durationMs = now.Sub(heartbeat.StartedAt).Milliseconds()
+	}
+
+	failedFlag := "0"
+	if failed {
+		failedFlag = "1"
+	}
+
+	pipe := redisClient.TxPipeline()
+	pipe.HSet(ctx, key, "last_ping_at", now.UnixMilli(), "failed", failedFlag, "last_duration_ms", durationMs)
+	pipe.HDel(ctx, key, "started_at")
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func parseUnixMilli(value string) time.Time {
+	milliseconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || milliseconds == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(milliseconds)
+}
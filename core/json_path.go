@@ -0,0 +1,105 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitJsonPath turns selectors like `$.services[0].status` or `services.0.status`
+// into their individual keys.
+func splitJsonPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+		for {
+			open := strings.Index(part, "[")
+			if open == -1 {
+				segments = append(segments, part)
+				break
+			}
+			closing := strings.Index(part, "]")
+			if closing < open {
+				return nil, fmt.Errorf("invalid json path: %s", path)
+			}
+			if open > 0 {
+				segments = append(segments, part[:open])
+			}
+			segments = append(segments, strings.Trim(part[open+1:closing], `'"`))
+			part = part[closing+1:]
+			if part == "" {
+				break
+			}
+		}
+	}
+	return segments, nil
+}
+
+// LookupJsonPath resolves path against a JSON document.
+func LookupJsonPath(document []byte, path string) (interface{}, error) {
+	segments, err := splitJsonPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var current interface{}
+	if err := json.Unmarshal(document, &current); err != nil {
+		return nil, fmt.Errorf("response body is not valid json: %w", err)
+	}
+
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("json path %s not found", path)
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("json path %s not found", path)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("json path %s not found", path)
+		}
+	}
+	return current, nil
+}
+
+// jsonValueToString renders a resolved value the way a user would type it on the CLI.
+func jsonValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
+
+// unquoteJsonValue strips surrounding quotes from a user supplied expected value.
+func unquoteJsonValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"github.com/horlerdipo/watchdog/enums"
+	"time"
+)
+
+// MonitorOptions holds the type specific settings of a monitor.
+// Only the fields relevant to the monitor's type are set.
+type MonitorOptions struct {
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	Send           string `json:"send,omitempty"`
+	Expect         string `json:"expect,omitempty"`
+
+	RecordType      enums.DnsRecordType `json:"record_type,omitempty"`
+	Resolver        string              `json:"resolver,omitempty"`
+	ExpectedAnswers []string            `json:"expected_answers,omitempty"`
+
+	GraceSeconds int `json:"grace_seconds,omitempty"`
+
+	GrpcService string `json:"grpc_service,omitempty"`
+	UseTLS      bool   `json:"use_tls,omitempty"`
+	StartTLS    bool   `json:"start_tls,omitempty"`
+
+	Steps []TransactionStep `json:"steps,omitempty"`
+
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"`
+
+	Query                    string `json:"query,omitempty"`
+	MaxReplicationLagSeconds int    `json:"max_replication_lag_seconds,omitempty"`
+
+	RedirectPolicy   enums.RedirectPolicy `json:"redirect_policy,omitempty"`
+	MaxRedirects     int                  `json:"max_redirects,omitempty"`
+	ExpectedFinalUrl string               `json:"expected_final_url,omitempty"`
+	ExpectedLocation string               `json:"expected_location,omitempty"`
+
+	DetectContentChanges bool              `json:"detect_content_changes,omitempty"`
+	ContentExclusions    ContentExclusions `json:"content_exclusions,omitempty"`
+}
+
+// Timeout returns the configured timeout, or fallback when none is set.
+func (mo MonitorOptions) Timeout(fallback time.Duration) time.Duration {
+	if mo.TimeoutSeconds > 0 {
+		return time.Duration(mo.TimeoutSeconds) * time.Second
+	}
+	return fallback
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const DefaultExpectedStatusCodes = "2xx"
+
+type statusCodeRule struct {
+	from    int
+	to      int
+	negated bool
+}
+
+func (r statusCodeRule) contains(code int) bool {
+	return code >= r.from && code <= r.to
+}
+
+// StatusCodeRules decides if a response status code is acceptable for a monitor.
+// Rules are comma separated and can be single codes (418), classes (2xx),
+// ranges (200-299) or negations of any of those (!301).
+type StatusCodeRules struct {
+	rules []statusCodeRule
+}
+
+func ParseStatusCodeRules(s string) (StatusCodeRules, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = DefaultExpectedStatusCodes
+	}
+
+	var parsed StatusCodeRules
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		rule := statusCodeRule{}
+		if strings.HasPrefix(part, "!") {
+			rule.negated = true
+			part = strings.TrimPrefix(part, "!")
+		}
+
+		switch {
+		case len(part) == 3 && strings.HasSuffix(part, "xx"):
+			class, err := strconv.Atoi(part[:1])
+			if err != nil || class < 1 || class > 5 {
+				return StatusCodeRules{}, fmt.Errorf("invalid status code class: %s", part)
+			}
+			rule.from = class * 100
+			rule.to = class*100 + 99
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			from, err := parseStatusCode(bounds[0])
+			if err != nil {
+				return StatusCodeRules{}, err
+			}
+			to, err := parseStatusCode(bounds[1])
+			if err != nil {
+				return StatusCodeRules{}, err
+			}
+			if from > to {
+				return StatusCodeRules{}, fmt.Errorf("invalid status code range: %s", part)
+			}
+			rule.from = from
+			rule.to = to
+		default:
+			code, err := parseStatusCode(part)
+			if err != nil {
+				return StatusCodeRules{}, err
+			}
+			rule.from = code
+			rule.to = code
+		}
+		parsed.rules = append(parsed.rules, rule)
+	}
+
+	if len(parsed.rules) == 0 {
+		return StatusCodeRules{}, fmt.Errorf("invalid expected status codes: %s", s)
+	}
+	return parsed, nil
+}
+
+// Matches reports whether code satisfies none of the negated rules and at least
+// one positive rule. With only negated rules, every other code is accepted.
+func (sr StatusCodeRules) Matches(code int) bool {
+	hasPositive := false
+	matched := false
+	for _, rule := range sr.rules {
+		if rule.negated {
+			if rule.contains(code) {
+				return false
+			}
+			continue
+		}
+		hasPositive = true
+		if rule.contains(code) {
+			matched = true
+		}
+	}
+	return matched || !hasPositive
+}
+
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code: %s", s)
+	}
+	return code, nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TLSConfig holds the client certificate and trust settings a monitor uses for TLS connections.
+// Files are referenced by path and read by the workers.
+type TLSConfig struct {
+	ClientCertFile     string `json:"client_cert_file,omitempty"`
+	ClientKeyFile      string `json:"client_key_file,omitempty"`
+	CaBundleFile       string `json:"ca_bundle_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+func (tc TLSConfig) Enabled() bool {
+	return tc.ClientCertFile != "" || tc.CaBundleFile != "" || tc.InsecureSkipVerify
+}
+
+func (tc TLSConfig) Validate() error {
+	if (tc.ClientCertFile == "") != (tc.ClientKeyFile == "") {
+		return fmt.Errorf("a client certificate requires both a certificate and a key file")
+	}
+	return nil
+}
+
+// CacheKey identifies the tls.Config this configuration resolves to.
+func (tc TLSConfig) CacheKey() string {
+	return strings.Join([]string{tc.ClientCertFile, tc.ClientKeyFile, tc.CaBundleFile, strconv.FormatBool(tc.InsecureSkipVerify)}, "|")
+}
+
+// Load reads the referenced files and builds the matching tls.Config.
+func (tc TLSConfig) Load() (*tls.Config, error) {
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tc.InsecureSkipVerify,
+	}
+
+	if tc.ClientCertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(tc.ClientCertFile, tc.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if tc.CaBundleFile != "" {
+		bundle, err := os.ReadFile(tc.CaBundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("ca bundle %s does not contain any PEM certificates", tc.CaBundleFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/horlerdipo/watchdog/enums"
+	"os"
+	"strings"
+)
+
+// TransactionStep is a single HTTP request of a multi-step transaction monitor.
+// Url, Headers and Body may reference variables extracted by earlier steps as {{name}}.
+type TransactionStep struct {
+	Name                string            `json:"name"`
+	Method              enums.HttpMethod  `json:"method"`
+	Url                 string            `json:"url"`
+	Headers             map[string]string `json:"headers,omitempty"`
+	Body                string            `json:"body,omitempty"`
+	ContentType         string            `json:"content_type,omitempty"`
+	ExpectedStatusCodes string            `json:"expected_status,omitempty"`
+	Assertions          []Assertion       `json:"assertions,omitempty"`
+	Extract             []Extraction      `json:"extract,omitempty"`
+}
+
+// Extraction stores part of a step's response in a variable for later steps.
+// Path is a json path for json extractions and the header or cookie name otherwise.
+type Extraction struct {
+	Variable string                 `json:"variable"`
+	Source   enums.ExtractionSource `json:"source"`
+	Path     string                 `json:"path"`
+}
+
+// StepResult is the outcome of one transaction step, stored with the check result.
+type StepResult struct {
+	Name      string `json:"name"`
+	LatencyMs int64  `json:"latency_ms"`
+	Success   bool   `json:"success"`
+}
+
+func LoadTransactionSteps(path string) ([]TransactionStep, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []TransactionStep
+	if err := json.Unmarshal(contents, &steps); err != nil {
+		return nil, fmt.Errorf("invalid steps file: %w", err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("steps file does not contain any steps")
+	}
+
+	for i := range steps {
+		if err := steps[i].normalize(); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return steps, nil
+}
+
+// normalize validates the step and rewrites methods and sources into their enum values.
+func (ts *TransactionStep) normalize() error {
+	if ts.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if ts.Url == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	method, err := enums.ParseHttpMethod(string(ts.Method))
+	if ts.Method == "" {
+		method, err = enums.Get, nil
+	}
+	if err != nil {
+		return err
+	}
+	ts.Method = method
+
+	if _, err := ParseStatusCodeRules(ts.ExpectedStatusCodes); err != nil {
+		return err
+	}
+	for _, assertion := range ts.Assertions {
+		if err := assertion.Validate(); err != nil {
+			return err
+		}
+	}
+	for i, extraction := range ts.Extract {
+		if extraction.Variable == "" || extraction.Path == "" {
+			return fmt.Errorf("extractions need a variable and a path")
+		}
+		source, err := enums.ParseExtractionSource(string(extraction.Source))
+		if err != nil {
+			return err
+		}
+		ts.Extract[i].Source = source
+	}
+	return nil
+}
+
+// ExtractJsonValue returns the value at path as a plain string.
+func ExtractJsonValue(document []byte, path string) (string, error) {
+	value, err := LookupJsonPath(document, path)
+	if err != nil {
+		return "", err
+	}
+	return jsonValueToString(value), nil
+}
+
+// SubstituteVariables replaces every {{name}} in s with its extracted value.
+func SubstituteVariables(s string, variables map[string]string) string {
+	if len(variables) == 0 || !strings.Contains(s, "{{") {
+		return s
+	}
+	for name, value := range variables {
+		s = strings.ReplaceAll(s, "{{"+name+"}}", value)
+	}
+	return s
+}
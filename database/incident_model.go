@@ -7,6 +7,7 @@ import (
 
 type Incident struct {
 	UrlId      int       `json:"url_id"`
+	FailedStep string    `json:"failed_step"`
 	ResolvedAt time.Time `json:"resolved_at"`
 	Time       time.Time `json:"time"`
 }
@@ -9,19 +9,20 @@ import (
 )
 
 type IncidentRepository interface {
-	Add(ctx context.Context, urlId int) error
+	Add(ctx context.Context, incident Incident) error
 	Resolve(ctx context.Context, incidentId int) error
 	Count(ctx context.Context, urlId int, numberOfDays int, dateType enums.DateType) (time.Time, int, error)
+	GetLatest(ctx context.Context, urlId int) (Incident, error)
 }
 
 type incidentRepository struct {
 	pool *pgxpool.Pool
 }
 
-func (inc incidentRepository) Add(ctx context.Context, urlId int) error {
-	sql := "INSERT INTO incidents (time, url_id) VALUES (NOW(), $1)"
+func (inc incidentRepository) Add(ctx context.Context, incident Incident) error {
+	sql := "INSERT INTO incidents (time, url_id, failed_step) VALUES (NOW(), $1, $2)"
 
-	_, err := inc.pool.Exec(ctx, sql, urlId)
+	_, err := inc.pool.Exec(ctx, sql, incident.UrlId, incident.FailedStep)
 	if err != nil {
 		return err
 	}
@@ -51,6 +52,22 @@ func (inc incidentRepository) Count(tx context.Context, urlId int, numberOfDays
 	return bucket, incidentCount, nil
 }
 
+// GetLatest returns the most recent incident of the url, resolved or not
+func (inc incidentRepository) GetLatest(ctx context.Context, urlId int) (Incident, error) {
+	sql := "SELECT time, url_id, failed_step, resolved_at FROM incidents WHERE url_id=$1 ORDER BY time DESC LIMIT 1"
+
+	var incident Incident
+	var resolvedAt *time.Time
+	err := inc.pool.QueryRow(ctx, sql, urlId).Scan(&incident.Time, &incident.UrlId, &incident.FailedStep, &resolvedAt)
+	if err != nil {
+		return Incident{}, err
+	}
+	if resolvedAt != nil {
+		incident.ResolvedAt = *resolvedAt
+	}
+	return incident, nil
+}
+
 func NewIncidentRepository(pool *pgxpool.Pool) IncidentRepository {
 	return incidentRepository{
 		pool: pool,
@@ -2,19 +2,60 @@ package database
 
 import (
 	"encoding/json"
+	"github.com/horlerdipo/watchdog/core"
 	"github.com/horlerdipo/watchdog/enums"
+	neturl "net/url"
+	"strings"
 	"time"
 )
 
 type Url struct {
-	Id                  int                       `json:"id"`
-	Url                 string                    `json:"url" redis:"url"`
-	HttpMethod          enums.HttpMethod          `json:"http_method" redis:"http_method"`
-	Status              enums.SiteHealth          `json:"status" redis:"status"`
-	MonitoringFrequency enums.MonitoringFrequency `json:"monitoring_frequency" redis:"monitoring_frequency"`
-	ContactEmail        string                    `json:"contact_email" redis:"contact_email"`
-	CreatedAt           time.Time                 `json:"created_at" redis:"created_at"`
-	UpdatedAt           time.Time                 `json:"updated_at" redis:"updated_at"`
+	Id                          int                       `json:"id"`
+	Url                         string                    `json:"url" redis:"url"`
+	Type                        enums.MonitorType         `json:"type" redis:"type"`
+	HttpMethod                  enums.HttpMethod          `json:"http_method" redis:"http_method"`
+	Status                      enums.SiteHealth          `json:"status" redis:"status"`
+	MonitoringFrequency         enums.MonitoringFrequency `json:"monitoring_frequency" redis:"monitoring_frequency"`
+	ContactEmail                string                    `json:"contact_email" redis:"contact_email"`
+	ExpectedStatusCodes         string                    `json:"expected_status_codes" redis:"expected_status_codes"`
+	Assertions                  []core.Assertion          `json:"assertions" redis:"assertions"`
+	Headers                     map[string]string         `json:"headers" redis:"headers"`
+	QueryParams                 map[string]string         `json:"query_params" redis:"query_params"`
+	RequestBody                 string                    `json:"request_body" redis:"request_body"`
+	ContentType                 string                    `json:"content_type" redis:"content_type"`
+	Auth                        core.AuthConfig           `json:"auth" redis:"auth"`
+	TLS                         core.TLSConfig            `json:"tls" redis:"tls"`
+	Proxy                       string                    `json:"proxy" redis:"proxy"`
+	LatencyThresholdMs          int                       `json:"latency_threshold_ms" redis:"latency_threshold_ms"`
+	FailureThreshold            int                       `json:"failure_threshold" redis:"failure_threshold"`
+	RecoveryThreshold           int                       `json:"recovery_threshold" redis:"recovery_threshold"`
+	CertificateExpiresAt        *time.Time                `json:"certificate_expires_at" redis:"certificate_expires_at"`
+	CertificateAlertedThreshold int                       `json:"certificate_alerted_threshold" redis:"certificate_alerted_threshold"`
+	Options                     core.MonitorOptions       `json:"options" redis:"options"`
+	HeartbeatToken              string                    `json:"heartbeat_token" redis:"heartbeat_token"`
+	ContentHash                 string                    `json:"content_hash" redis:"content_hash"`
+	ContentAlertedHash          string                    `json:"content_alerted_hash" redis:"content_alerted_hash"`
+	FlappingSince               *time.Time                `json:"flapping_since" redis:"flapping_since"`
+	StatusChangedAt             time.Time                 `json:"status_changed_at" redis:"status_changed_at"`
+	CreatedAt                   time.Time                 `json:"created_at" redis:"created_at"`
+	UpdatedAt                   time.Time                 `json:"updated_at" redis:"updated_at"`
+}
+
+// DisplayUrl returns the url with any password masked, as database monitors keep credentials in their DSN.
+func (url Url) DisplayUrl() string {
+	parsed, err := neturl.Parse(url.Url)
+	if err == nil && parsed.User != nil {
+		return parsed.Redacted()
+	}
+
+	//MySQL DSNs are not urls: user:password@tcp(host:3306)/database
+	if url.Type == enums.Mysql {
+		at := strings.LastIndex(url.Url, "@")
+		if user, _, found := strings.Cut(url.Url[:max(at, 0)], ":"); found {
+			return user + ":xxxxx" + url.Url[at:]
+		}
+	}
+	return url.Url
 }
 
 func (url Url) MarshalBinary() (data []byte, err error) {
@@ -3,12 +3,18 @@ package database
 import (
 	"context"
 	"fmt"
+	"github.com/horlerdipo/watchdog/core"
 	"github.com/horlerdipo/watchdog/enums"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"strings"
+	"time"
 )
 
+const urlColumns = "id,url,monitor_type,http_method,contact_email,status,monitoring_frequency,expected_status_codes,assertions,headers,query_params,request_body,content_type,auth,tls,proxy,latency_threshold_ms,failure_threshold,recovery_threshold,certificate_expires_at,certificate_alerted_threshold,options,heartbeat_token,content_hash,content_alerted_hash,flapping_since,status_changed_at,created_at,updated_at"
+
 type UrlQueryFilter struct {
+	Type       enums.MonitorType
 	HttpMethod enums.HttpMethod
 	Status     enums.SiteHealth
 	Frequency  enums.MonitoringFrequency
@@ -20,22 +26,35 @@ func NewUrlQueryFilter() UrlQueryFilter {
 
 type UrlRepository interface {
 	FetchAll(ctx context.Context, limit int, offset int, filter UrlQueryFilter) ([]Url, error)
-	Add(ctx context.Context, url string, httpMethod enums.HttpMethod, frequency enums.MonitoringFrequency, contactEmail string) (int, error)
+	Add(ctx context.Context, url Url) (int, error)
 	Delete(ctx context.Context, Id int) error
 	FindById(ctx context.Context, Id int) (Url, error)
+	FindByHeartbeatToken(ctx context.Context, token string) (Url, error)
 	UpdateStatus(ctx context.Context, Id int, status enums.SiteHealth) error
+	TransitionStatus(ctx context.Context, Id int, from enums.SiteHealth, to enums.SiteHealth) (bool, error)
+	UpdateCertificateExpiry(ctx context.Context, Id int, expiresAt time.Time) error
+	UpdateCertificateAlertedThreshold(ctx context.Context, Id int, threshold int) error
+	UpdateContentBaseline(ctx context.Context, Id int, hash string) error
+	UpdateContentAlertedHash(ctx context.Context, Id int, hash string) error
+	UpdateFlappingSince(ctx context.Context, Id int, since *time.Time) error
 }
 type urlRepository struct {
 	pool *pgxpool.Pool
 }
 
 func (ur urlRepository) FetchAll(ctx context.Context, limit int, offset int, filter UrlQueryFilter) ([]Url, error) {
-	sql := "SELECT id,url,http_method,contact_email,status,monitoring_frequency,created_at,updated_at FROM urls"
+	sql := "SELECT " + urlColumns + " FROM urls"
 
 	var whereClauses []string
 	var args []interface{}
 	argPosition := 1
 
+	if filter.Type != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("monitor_type = $%d", argPosition))
+		args = append(args, filter.Type.ToString())
+		argPosition++
+	}
+
 	if filter.HttpMethod != "" {
 		whereClauses = append(whereClauses, fmt.Sprintf("http_method = $%d", argPosition))
 		args = append(args, filter.HttpMethod.ToString())
@@ -68,57 +87,61 @@ func (ur urlRepository) FetchAll(ctx context.Context, limit int, offset int, fil
 	defer rows.Close()
 
 	var urls []Url
-	var monitoringFrequency string
-	var status string
-	var httpMethod string
-
 	for rows.Next() {
-		var url Url
-		err := rows.Scan(
-			&url.Id,
-			&url.Url,
-			&httpMethod,
-			&url.ContactEmail,
-			&status,
-			&monitoringFrequency,
-			&url.CreatedAt,
-			&url.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		parsedMonitoringFrequency, err := enums.ParseMonitoringFrequency(monitoringFrequency)
-		if err != nil {
-			return nil, err
-		}
-
-		parsedStatus, err := enums.ParseSiteHealth(status)
+		url, err := scanUrl(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		parsedHttpMethod, err := enums.ParseHttpMethod(httpMethod)
-		if err != nil {
-			return nil, err
-		}
-
-		url.MonitoringFrequency = parsedMonitoringFrequency
-		url.Status = parsedStatus
-		url.HttpMethod = parsedHttpMethod
 		urls = append(urls, url)
-		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("error iterating task rows: %w", err)
-		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating url rows: %w", err)
 	}
 	return urls, nil
 }
 
-func (ur urlRepository) Add(ctx context.Context, url string, httpMethod enums.HttpMethod, frequency enums.MonitoringFrequency, contactEmail string) (int, error) {
-	sql := "INSERT INTO urls (url,http_method,contact_email,status,monitoring_frequency) VALUES ($1,$2,$3,$4,$5) RETURNING id"
+func (ur urlRepository) Add(ctx context.Context, url Url) (int, error) {
+	sql := "INSERT INTO urls (url,http_method,contact_email,status,monitoring_frequency,expected_status_codes,assertions,headers,query_params,request_body,content_type,auth,tls,proxy,latency_threshold_ms,failure_threshold,recovery_threshold,monitor_type,options,heartbeat_token) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20) RETURNING id"
+
+	if url.Type == "" {
+		url.Type = enums.Http
+	}
+
+	if url.Assertions == nil {
+		url.Assertions = []core.Assertion{}
+	}
+	if url.Headers == nil {
+		url.Headers = map[string]string{}
+	}
+	if url.QueryParams == nil {
+		url.QueryParams = map[string]string{}
+	}
 
 	var id int
-	err := ur.pool.QueryRow(ctx, sql, url, httpMethod, contactEmail, enums.Pending, frequency).Scan(&id)
+	err := ur.pool.QueryRow(
+		ctx,
+		sql,
+		url.Url,
+		url.HttpMethod,
+		url.ContactEmail,
+		enums.Pending,
+		url.MonitoringFrequency,
+		url.ExpectedStatusCodes,
+		url.Assertions,
+		url.Headers,
+		url.QueryParams,
+		url.RequestBody,
+		url.ContentType,
+		url.Auth,
+		url.TLS,
+		url.Proxy,
+		url.LatencyThresholdMs,
+		url.FailureThreshold,
+		url.RecoveryThreshold,
+		url.Type,
+		url.Options,
+		url.HeartbeatToken,
+	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -126,25 +149,131 @@ func (ur urlRepository) Add(ctx context.Context, url string, httpMethod enums.Ht
 }
 
 func (ur urlRepository) FindById(ctx context.Context, id int) (Url, error) {
-	sql := "SELECT id,url,http_method,contact_email,status,monitoring_frequency,created_at,updated_at FROM urls WHERE ID=$1"
+	sql := "SELECT " + urlColumns + " FROM urls WHERE ID=$1"
+	return scanUrl(ur.pool.QueryRow(ctx, sql, id))
+}
+
+func (ur urlRepository) FindByHeartbeatToken(ctx context.Context, token string) (Url, error) {
+	sql := "SELECT " + urlColumns + " FROM urls WHERE monitor_type=$1 AND heartbeat_token=$2"
+	return scanUrl(ur.pool.QueryRow(ctx, sql, enums.Heartbeat, token))
+}
+
+func (ur urlRepository) Delete(ctx context.Context, Id int) error {
+	sql := "DELETE FROM urls WHERE id=$1"
+	_, err := ur.pool.Exec(ctx, sql, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur urlRepository) UpdateStatus(ctx context.Context, Id int, status enums.SiteHealth) error {
+	sql := "UPDATE urls SET status=$1, status_changed_at=NOW() WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, status, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// TransitionStatus only changes the status while it still is from, and reports whether it did
+func (ur urlRepository) TransitionStatus(ctx context.Context, Id int, from enums.SiteHealth, to enums.SiteHealth) (bool, error) {
+	sql := "UPDATE urls SET status=$1, status_changed_at=NOW() WHERE id=$2 AND status=$3"
+	result, err := ur.pool.Exec(ctx, sql, to, Id, from)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (ur urlRepository) UpdateCertificateExpiry(ctx context.Context, Id int, expiresAt time.Time) error {
+	//a renewed certificate starts a new round of expiry alerts
+	sql := "UPDATE urls SET certificate_alerted_threshold = CASE WHEN certificate_expires_at IS DISTINCT FROM $1 THEN 0 ELSE certificate_alerted_threshold END, certificate_expires_at=$1 WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, expiresAt, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur urlRepository) UpdateCertificateAlertedThreshold(ctx context.Context, Id int, threshold int) error {
+	sql := "UPDATE urls SET certificate_alerted_threshold=$1 WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, threshold, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateContentBaseline accepts hash as the expected content and re-arms change alerts.
+func (ur urlRepository) UpdateContentBaseline(ctx context.Context, Id int, hash string) error {
+	sql := "UPDATE urls SET content_hash=$1, content_alerted_hash='' WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, hash, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur urlRepository) UpdateContentAlertedHash(ctx context.Context, Id int, hash string) error {
+	sql := "UPDATE urls SET content_alerted_hash=$1 WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, hash, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ur urlRepository) UpdateFlappingSince(ctx context.Context, Id int, since *time.Time) error {
+	sql := "UPDATE urls SET flapping_since=$1 WHERE id=$2"
+	_, err := ur.pool.Exec(ctx, sql, since, Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func scanUrl(row pgx.Row) (Url, error) {
 	var url Url
 	var monitoringFrequency string
 	var status string
 	var httpMethod string
-	err := ur.pool.QueryRow(ctx, sql, id).Scan(
+	var monitorType string
+	err := row.Scan(
 		&url.Id,
 		&url.Url,
+		&monitorType,
 		&httpMethod,
 		&url.ContactEmail,
 		&status,
 		&monitoringFrequency,
+		&url.ExpectedStatusCodes,
+		&url.Assertions,
+		&url.Headers,
+		&url.QueryParams,
+		&url.RequestBody,
+		&url.ContentType,
+		&url.Auth,
+		&url.TLS,
+		&url.Proxy,
+		&url.LatencyThresholdMs,
+		&url.FailureThreshold,
+		&url.RecoveryThreshold,
+		&url.CertificateExpiresAt,
+		&url.CertificateAlertedThreshold,
+		&url.Options,
+		&url.HeartbeatToken,
+		&url.ContentHash,
+		&url.ContentAlertedHash,
+		&url.FlappingSince,
+		&url.StatusChangedAt,
 		&url.CreatedAt,
 		&url.UpdatedAt,
 	)
-
 	if err != nil {
-		return url, err
+		return Url{}, err
 	}
+
 	parsedMonitoringFrequency, err := enums.ParseMonitoringFrequency(monitoringFrequency)
 	if err != nil {
 		return Url{}, err
@@ -160,31 +289,18 @@ func (ur urlRepository) FindById(ctx context.Context, id int) (Url, error) {
 		return Url{}, err
 	}
 
+	parsedMonitorType, err := enums.ParseMonitorType(monitorType)
+	if err != nil {
+		return Url{}, err
+	}
+
 	url.MonitoringFrequency = parsedMonitoringFrequency
 	url.Status = parsedStatus
 	url.HttpMethod = parsedHttpMethod
-
+	url.Type = parsedMonitorType
 	return url, nil
 }
 
-func (ur urlRepository) Delete(ctx context.Context, Id int) error {
-	sql := "DELETE FROM urls WHERE id=$1"
-	_, err := ur.pool.Exec(ctx, sql, Id)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (ur urlRepository) UpdateStatus(ctx context.Context, Id int, status enums.SiteHealth) error {
-	sql := "UPDATE urls SET status=$1 WHERE id=$2"
-	_, err := ur.pool.Exec(ctx, sql, status, Id)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func NewUrlRepository(pool *pgxpool.Pool) UrlRepository {
 	return urlRepository{
 		pool: pool,
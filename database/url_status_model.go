@@ -2,13 +2,23 @@ package database
 
 import (
 	"encoding/json"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/enums"
 	"time"
 )
 
 type UrlStatus struct {
-	UrlId  int       `json:"url_id"`
-	Status bool      `json:"status"`
-	Time   time.Time `json:"time"`
+	UrlId         int               `json:"url_id"`
+	Status        enums.SiteHealth  `json:"status"`
+	LatencyMs     int64             `json:"latency_ms"`
+	Steps         []core.StepResult `json:"steps"`
+	Redirects     []string          `json:"redirects"`
+	Output        string            `json:"output"`
+	PerfData      string            `json:"perf_data"`
+	ContentHash   string            `json:"content_hash"`
+	FailureCode   enums.FailureCode `json:"failure_code"`
+	FailureReason string            `json:"failure_reason"`
+	Time          time.Time         `json:"time"`
 }
 
 func (url UrlStatus) MarshalBinary() (data []byte, err error) {
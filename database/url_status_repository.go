@@ -2,40 +2,82 @@ package database
 
 import (
 	"context"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const urlStatusColumns = "time,url_id,status,latency_ms,steps,redirects,output,perf_data,content_hash,failure_code,failure_reason"
+
 type UrlStatusRepository interface {
-	Add(ctx context.Context, urlId int, status bool) error
-	GetRecentStatus(ctx context.Context, urlId int, status bool) (UrlStatus, error)
+	Add(ctx context.Context, urlStatus UrlStatus) error
+	GetRecentStatus(ctx context.Context, urlId int, statuses ...enums.SiteHealth) (UrlStatus, error)
 	GetLastStatus(ctx context.Context, urlId int) (UrlStatus, error)
+	GetLastContentHash(ctx context.Context, urlId int) (string, error)
 }
 type urlStatusRepository struct {
 	pool *pgxpool.Pool
 }
 
-func (ur urlStatusRepository) Add(ctx context.Context, urlId int, status bool) error {
-	sql := "INSERT INTO url_statuses (time, url_id,status) VALUES (NOW(), $1,$2)"
+func (ur urlStatusRepository) Add(ctx context.Context, urlStatus UrlStatus) error {
+	sql := "INSERT INTO url_statuses (time, url_id,status,latency_ms,steps,redirects,output,perf_data,content_hash,failure_code,failure_reason) VALUES (NOW(), $1,$2,$3,$4,$5,$6,$7,$8,$9,$10)"
 
-	_, err := ur.pool.Exec(ctx, sql, urlId, status)
+	if urlStatus.Steps == nil {
+		urlStatus.Steps = []core.StepResult{}
+	}
+	if urlStatus.Redirects == nil {
+		urlStatus.Redirects = []string{}
+	}
+
+	_, err := ur.pool.Exec(ctx, sql, urlStatus.UrlId, urlStatus.Status, urlStatus.LatencyMs, urlStatus.Steps, urlStatus.Redirects, urlStatus.Output, urlStatus.PerfData, urlStatus.ContentHash, urlStatus.FailureCode, urlStatus.FailureReason)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (ur urlStatusRepository) GetRecentStatus(ctx context.Context, urlId int, status bool) (UrlStatus, error) {
-	var urlStatus UrlStatus
-	sql := "SELECT * FROM url_statuses WHERE url_id=$1 AND STATUS=$2 ORDER BY time DESC LIMIT 1"
-	err := ur.pool.QueryRow(ctx, sql, urlId, status).Scan(&urlStatus.Time, &urlStatus.UrlId, &urlStatus.Status)
-	return urlStatus, err
+// GetRecentStatus returns the most recent check of the url that ended in one of the given statuses
+func (ur urlStatusRepository) GetRecentStatus(ctx context.Context, urlId int, statuses ...enums.SiteHealth) (UrlStatus, error) {
+	sql := "SELECT " + urlStatusColumns + " FROM url_statuses WHERE url_id=$1 AND status::text = ANY($2) ORDER BY time DESC LIMIT 1"
+
+	var names []string
+	for _, status := range statuses {
+		names = append(names, status.ToString())
+	}
+	return scanUrlStatus(ur.pool.QueryRow(ctx, sql, urlId, names))
 }
 
 func (ur urlStatusRepository) GetLastStatus(ctx context.Context, urlId int) (UrlStatus, error) {
+	sql := "SELECT " + urlStatusColumns + " FROM url_statuses WHERE url_id=$1 ORDER BY time DESC LIMIT 1"
+	return scanUrlStatus(ur.pool.QueryRow(ctx, sql, urlId))
+}
+
+func (ur urlStatusRepository) GetLastContentHash(ctx context.Context, urlId int) (string, error) {
+	sql := "SELECT content_hash FROM url_statuses WHERE url_id=$1 AND content_hash <> '' ORDER BY time DESC LIMIT 1"
+	var hash string
+	err := ur.pool.QueryRow(ctx, sql, urlId).Scan(&hash)
+	return hash, err
+}
+
+func scanUrlStatus(row pgx.Row) (UrlStatus, error) {
 	var urlStatus UrlStatus
-	sql := "SELECT * FROM url_statuses WHERE url_id=$1 ORDER BY time DESC LIMIT 1"
-	err := ur.pool.QueryRow(ctx, sql, urlId).Scan(&urlStatus.Time, &urlStatus.UrlId, &urlStatus.Status)
-	return urlStatus, err
+	var status string
+	var failureCode string
+	err := row.Scan(&urlStatus.Time, &urlStatus.UrlId, &status, &urlStatus.LatencyMs, &urlStatus.Steps, &urlStatus.Redirects, &urlStatus.Output, &urlStatus.PerfData, &urlStatus.ContentHash, &failureCode, &urlStatus.FailureReason)
+	if err != nil {
+		return UrlStatus{}, err
+	}
+
+	urlStatus.Status, err = enums.ParseSiteHealth(status)
+	if err != nil {
+		return UrlStatus{}, err
+	}
+	urlStatus.FailureCode, err = enums.ParseFailureCode(failureCode)
+	if err != nil {
+		return UrlStatus{}, err
+	}
+	return urlStatus, nil
 }
 
 func NewUrlStatusRepository(pool *pgxpool.Pool) UrlStatusRepository {
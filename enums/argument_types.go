@@ -3,6 +3,8 @@ package enums
 type ArgumentType string
 
 const (
-	Int    ArgumentType = "int"
-	String ArgumentType = "string"
+	Int         ArgumentType = "int"
+	String      ArgumentType = "string"
+	StringSlice ArgumentType = "string_slice"
+	Bool        ArgumentType = "bool"
 )
@@ -0,0 +1,68 @@
+package enums
+
+import (
+	"fmt"
+)
+
+type AssertionOperator string
+
+const (
+	Equals             AssertionOperator = "=="
+	NotEquals          AssertionOperator = "!="
+	In                 AssertionOperator = "in"
+	GreaterThan        AssertionOperator = ">"
+	GreaterThanOrEqual AssertionOperator = ">="
+	LessThan           AssertionOperator = "<"
+	LessThanOrEqual    AssertionOperator = "<="
+)
+
+func (ao AssertionOperator) ToString() string {
+	switch ao {
+	case Equals:
+		return "=="
+	case NotEquals:
+		return "!="
+	case In:
+		return "in"
+	case GreaterThan:
+		return ">"
+	case GreaterThanOrEqual:
+		return ">="
+	case LessThan:
+		return "<"
+	case LessThanOrEqual:
+		return "<="
+	default:
+		return ""
+	}
+}
+
+func (ao AssertionOperator) IsNumeric() bool {
+	switch ao {
+	case GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParseAssertionOperator(s string) (AssertionOperator, error) {
+	switch s {
+	case "==", "=", "eq":
+		return Equals, nil
+	case "!=", "ne":
+		return NotEquals, nil
+	case "in":
+		return In, nil
+	case ">", "gt":
+		return GreaterThan, nil
+	case ">=", "gte":
+		return GreaterThanOrEqual, nil
+	case "<", "lt":
+		return LessThan, nil
+	case "<=", "lte":
+		return LessThanOrEqual, nil
+	default:
+		return "", fmt.Errorf("invalid assertion operator: %s", s)
+	}
+}
@@ -0,0 +1,45 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AssertionType string
+
+const (
+	Contains    AssertionType = "contains"
+	NotContains AssertionType = "not_contains"
+	Regex       AssertionType = "regex"
+	Json        AssertionType = "json"
+)
+
+func (at AssertionType) ToString() string {
+	switch at {
+	case Contains:
+		return "contains"
+	case NotContains:
+		return "not_contains"
+	case Regex:
+		return "regex"
+	case Json:
+		return "json"
+	default:
+		return ""
+	}
+}
+
+func ParseAssertionType(s string) (AssertionType, error) {
+	switch strings.ToLower(s) {
+	case "contains":
+		return Contains, nil
+	case "not_contains":
+		return NotContains, nil
+	case "regex":
+		return Regex, nil
+	case "json":
+		return Json, nil
+	default:
+		return "", fmt.Errorf("invalid assertion type: %s", s)
+	}
+}
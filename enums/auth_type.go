@@ -0,0 +1,45 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AuthType string
+
+const (
+	NoAuth     AuthType = "none"
+	BasicAuth  AuthType = "basic"
+	BearerAuth AuthType = "bearer"
+	OAuth2     AuthType = "oauth2"
+)
+
+func (at AuthType) ToString() string {
+	switch at {
+	case NoAuth:
+		return "none"
+	case BasicAuth:
+		return "basic"
+	case BearerAuth:
+		return "bearer"
+	case OAuth2:
+		return "oauth2"
+	default:
+		return ""
+	}
+}
+
+func ParseAuthType(s string) (AuthType, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return NoAuth, nil
+	case "basic":
+		return BasicAuth, nil
+	case "bearer":
+		return BearerAuth, nil
+	case "oauth2":
+		return OAuth2, nil
+	default:
+		return "", fmt.Errorf("invalid auth type: %s", s)
+	}
+}
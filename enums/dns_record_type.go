@@ -0,0 +1,50 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DnsRecordType string
+
+const (
+	ARecord     DnsRecordType = "A"
+	AAAARecord  DnsRecordType = "AAAA"
+	CNAMERecord DnsRecordType = "CNAME"
+	MXRecord    DnsRecordType = "MX"
+	TXTRecord   DnsRecordType = "TXT"
+)
+
+func (dt DnsRecordType) ToString() string {
+	switch dt {
+	case ARecord:
+		return "A"
+	case AAAARecord:
+		return "AAAA"
+	case CNAMERecord:
+		return "CNAME"
+	case MXRecord:
+		return "MX"
+	case TXTRecord:
+		return "TXT"
+	default:
+		return ""
+	}
+}
+
+func ParseDnsRecordType(s string) (DnsRecordType, error) {
+	switch strings.ToUpper(s) {
+	case "", "A":
+		return ARecord, nil
+	case "AAAA":
+		return AAAARecord, nil
+	case "CNAME":
+		return CNAMERecord, nil
+	case "MX":
+		return MXRecord, nil
+	case "TXT":
+		return TXTRecord, nil
+	default:
+		return "", fmt.Errorf("invalid dns record type: %s", s)
+	}
+}
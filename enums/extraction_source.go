@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ExtractionSource string
+
+const (
+	JsonSource   ExtractionSource = "json"
+	HeaderSource ExtractionSource = "header"
+	CookieSource ExtractionSource = "cookie"
+)
+
+func (es ExtractionSource) ToString() string {
+	switch es {
+	case JsonSource:
+		return "json"
+	case HeaderSource:
+		return "header"
+	case CookieSource:
+		return "cookie"
+	default:
+		return ""
+	}
+}
+
+func ParseExtractionSource(s string) (ExtractionSource, error) {
+	switch strings.ToLower(s) {
+	case "json":
+		return JsonSource, nil
+	case "header":
+		return HeaderSource, nil
+	case "cookie":
+		return CookieSource, nil
+	default:
+		return "", fmt.Errorf("invalid extraction source: %s", s)
+	}
+}
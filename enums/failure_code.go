@@ -0,0 +1,90 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FailureCode classifies why a check failed
+type FailureCode string
+
+const (
+	DnsResolutionFailed FailureCode = "dns_resolution"
+	ConnectionRefused   FailureCode = "connection_refused"
+	TimedOut            FailureCode = "timeout"
+	TlsHandshakeFailed  FailureCode = "tls_handshake"
+	UnexpectedStatus    FailureCode = "unexpected_status"
+	AssertionFailed     FailureCode = "assertion_failed"
+	//AuthFailed means the credentials for the check could not be obtained, e.g. a failing OAuth2 token request
+	AuthFailed FailureCode = "auth_failed"
+	//ProxyFailed means the proxy the check is sent through could not be reached or refused the check
+	ProxyFailed FailureCode = "proxy_failed"
+	//ConnectionFailed covers the remaining network errors, e.g. resets and unreachable hosts
+	ConnectionFailed FailureCode = "connection_failed"
+	//UnknownResult means the check could not tell whether the service works, e.g. an UNKNOWN plugin.
+	//It is recorded, but never takes a site down
+	UnknownResult FailureCode = "unknown"
+	//CheckFailed covers everything else, e.g. missing certificates, a broken plugin or a job reporting a failure
+	CheckFailed FailureCode = "check_failed"
+)
+
+func (fc FailureCode) ToString() string {
+	switch fc {
+	case DnsResolutionFailed:
+		return "dns_resolution"
+	case ConnectionRefused:
+		return "connection_refused"
+	case TimedOut:
+		return "timeout"
+	case TlsHandshakeFailed:
+		return "tls_handshake"
+	case UnexpectedStatus:
+		return "unexpected_status"
+	case AssertionFailed:
+		return "assertion_failed"
+	case AuthFailed:
+		return "auth_failed"
+	case ProxyFailed:
+		return "proxy_failed"
+	case ConnectionFailed:
+		return "connection_failed"
+	case UnknownResult:
+		return "unknown"
+	case CheckFailed:
+		return "check_failed"
+	default:
+		return ""
+	}
+}
+
+// ParseFailureCode accepts an empty code, which successful checks are stored with
+func ParseFailureCode(s string) (FailureCode, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return "", nil
+	case "dns_resolution":
+		return DnsResolutionFailed, nil
+	case "connection_refused":
+		return ConnectionRefused, nil
+	case "timeout":
+		return TimedOut, nil
+	case "tls_handshake":
+		return TlsHandshakeFailed, nil
+	case "unexpected_status":
+		return UnexpectedStatus, nil
+	case "assertion_failed":
+		return AssertionFailed, nil
+	case "auth_failed":
+		return AuthFailed, nil
+	case "proxy_failed":
+		return ProxyFailed, nil
+	case "connection_failed":
+		return ConnectionFailed, nil
+	case "unknown":
+		return UnknownResult, nil
+	case "check_failed":
+		return CheckFailed, nil
+	default:
+		return "", fmt.Errorf("invalid failure code: %s", s)
+	}
+}
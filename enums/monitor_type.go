@@ -0,0 +1,95 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MonitorType string
+
+const (
+	Http        MonitorType = "http"
+	Tcp         MonitorType = "tcp"
+	Dns         MonitorType = "dns"
+	Heartbeat   MonitorType = "heartbeat"
+	Grpc        MonitorType = "grpc"
+	WebSocket   MonitorType = "websocket"
+	Transaction MonitorType = "transaction"
+	Exec        MonitorType = "exec"
+	Postgres    MonitorType = "postgres"
+	Mysql       MonitorType = "mysql"
+	Redis       MonitorType = "redis"
+	Smtp        MonitorType = "smtp"
+	Imap        MonitorType = "imap"
+	Pop3        MonitorType = "pop3"
+)
+
+func (mt MonitorType) ToString() string {
+	switch mt {
+	case Http:
+		return "http"
+	case Tcp:
+		return "tcp"
+	case Dns:
+		return "dns"
+	case Heartbeat:
+		return "heartbeat"
+	case Grpc:
+		return "grpc"
+	case WebSocket:
+		return "websocket"
+	case Transaction:
+		return "transaction"
+	case Exec:
+		return "exec"
+	case Postgres:
+		return "postgres"
+	case Mysql:
+		return "mysql"
+	case Redis:
+		return "redis"
+	case Smtp:
+		return "smtp"
+	case Imap:
+		return "imap"
+	case Pop3:
+		return "pop3"
+	default:
+		return ""
+	}
+}
+
+func ParseMonitorType(s string) (MonitorType, error) {
+	switch strings.ToLower(s) {
+	case "", "http":
+		return Http, nil
+	case "tcp":
+		return Tcp, nil
+	case "dns":
+		return Dns, nil
+	case "heartbeat":
+		return Heartbeat, nil
+	case "grpc":
+		return Grpc, nil
+	case "websocket":
+		return WebSocket, nil
+	case "transaction":
+		return Transaction, nil
+	case "exec":
+		return Exec, nil
+	case "postgres":
+		return Postgres, nil
+	case "mysql":
+		return Mysql, nil
+	case "redis":
+		return Redis, nil
+	case "smtp":
+		return Smtp, nil
+	case "imap":
+		return Imap, nil
+	case "pop3":
+		return Pop3, nil
+	default:
+		return "", fmt.Errorf("invalid monitor type: %s", s)
+	}
+}
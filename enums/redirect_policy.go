@@ -0,0 +1,35 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RedirectPolicy string
+
+const (
+	FollowRedirects   RedirectPolicy = "follow"
+	NoFollowRedirects RedirectPolicy = "none"
+)
+
+func (rp RedirectPolicy) ToString() string {
+	switch rp {
+	case FollowRedirects:
+		return "follow"
+	case NoFollowRedirects:
+		return "none"
+	default:
+		return ""
+	}
+}
+
+func ParseRedirectPolicy(s string) (RedirectPolicy, error) {
+	switch strings.ToLower(s) {
+	case "", "follow":
+		return FollowRedirects, nil
+	case "none":
+		return NoFollowRedirects, nil
+	default:
+		return "", fmt.Errorf("invalid redirect policy: %s", s)
+	}
+}
@@ -11,6 +11,13 @@ const (
 	Pending   SiteHealth = "pending"
 	Healthy   SiteHealth = "healthy"
 	UnHealthy SiteHealth = "unhealthy"
+	Degraded  SiteHealth = "degraded"
+	//Paused monitors are not checked at all
+	Paused SiteHealth = "paused"
+	//Maintenance monitors are checked and recorded, but never change status or alert
+	Maintenance SiteHealth = "maintenance"
+	//Unknown is only recorded for checks that could not tell whether the site is up, never set on a url
+	Unknown SiteHealth = "unknown"
 )
 
 func (sh SiteHealth) ToString() string {
@@ -21,6 +28,14 @@ func (sh SiteHealth) ToString() string {
 		return "healthy"
 	case UnHealthy:
 		return "unhealthy"
+	case Degraded:
+		return "degraded"
+	case Paused:
+		return "paused"
+	case Maintenance:
+		return "maintenance"
+	case Unknown:
+		return "unknown"
 	default:
 		return ""
 	}
@@ -33,7 +48,25 @@ func ParseSiteHealth(s string) (SiteHealth, error) {
 		return Healthy, nil
 	case "unhealthy":
 		return UnHealthy, nil
+	case "degraded":
+		return Degraded, nil
+	case "paused":
+		return Paused, nil
+	case "maintenance":
+		return Maintenance, nil
+	case "unknown":
+		return Unknown, nil
 	default:
 		return "", fmt.Errorf("invalid site health option: %s", s)
 	}
 }
+
+// IsUp reports whether the last checks found the site reachable
+func (sh SiteHealth) IsUp() bool {
+	return sh == Healthy || sh == Degraded
+}
+
+// IsManual reports whether the status was set by the owner rather than by checks
+func (sh SiteHealth) IsManual() bool {
+	return sh == Paused || sh == Maintenance
+}
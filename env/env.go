@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 func LoadEnv(environmentFileName string) {
@@ -43,3 +44,23 @@ func FetchInt(key string, fallback ...int) int {
 	}
 	return resp
 }
+
+func FetchIntSlice(key string, fallback ...[]int) []int {
+	response, ok := os.LookupEnv(key)
+	if ok == false && len(fallback) <= 0 {
+		panic(fmt.Sprintf("environment variable %s is not set and no fallback provided", key))
+	}
+
+	var resp []int
+	for _, part := range strings.Split(response, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			if len(fallback) > 0 {
+				return fallback[0]
+			}
+			panic(fmt.Sprintf("environment variable %s is not a comma separated list of integers", key))
+		}
+		resp = append(resp, value)
+	}
+	return resp
+}
@@ -0,0 +1,19 @@
+package events
+
+import "time"
+
+type CertificateExpiring struct {
+	UrlId         int
+	Url           string
+	ExpiresAt     time.Time
+	DaysRemaining int
+	Threshold     int
+}
+
+func (c *CertificateExpiring) Name() string {
+	return "certificate.expiring"
+}
+
+func (c *CertificateExpiring) OrderingKey() string {
+	return urlOrderingKey(c.UrlId)
+}
@@ -0,0 +1,16 @@
+package events
+
+type ContentChanged struct {
+	UrlId        int
+	Url          string
+	BaselineHash string
+	Hash         string
+}
+
+func (c *ContentChanged) Name() string {
+	return "content.changed"
+}
+
+func (c *ContentChanged) OrderingKey() string {
+	return urlOrderingKey(c.UrlId)
+}
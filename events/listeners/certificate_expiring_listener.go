@@ -0,0 +1,59 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+type CertificateExpiringListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (cl *CertificateExpiringListener) Handle(event core.Event) {
+	e := event.(*events.CertificateExpiring)
+	fmt.Printf("certificate for %v expires in %v days, sending email out \n", e.Url, e.DaysRemaining)
+
+	urlRepo := database.NewUrlRepository(cl.DB)
+	url, err := urlRepo.FindById(cl.ctx, e.UrlId)
+	if err != nil {
+		cl.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	//this certificate was already alerted on at this threshold or a closer one
+	if url.CertificateAlertedThreshold != 0 && url.CertificateAlertedThreshold <= e.Threshold &&
+		url.CertificateExpiresAt != nil && url.CertificateExpiresAt.Equal(e.ExpiresAt) {
+		return
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     "Your Site's TLS certificate is expiring",
+		Content:     fmt.Sprintf("The TLS certificate for `%v` expires at %v, which is in %d day(s). Please renew it.", url.DisplayUrl(), e.ExpiresAt, e.DaysRemaining),
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		cl.logger.Error("Error sending certificate expiry email: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	err = urlRepo.UpdateCertificateAlertedThreshold(cl.ctx, e.UrlId, e.Threshold)
+	if err != nil {
+		cl.logger.Error("Unable to update certificate alerted threshold: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewCertificateExpiringListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *CertificateExpiringListener {
+	return &CertificateExpiringListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
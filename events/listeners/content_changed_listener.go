@@ -0,0 +1,60 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+type ContentChangedListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (cl *ContentChangedListener) Handle(event core.Event) {
+	e := event.(*events.ContentChanged)
+	fmt.Printf("content of %v changed, sending email out \n", e.Url)
+
+	urlRepo := database.NewUrlRepository(cl.DB)
+	url, err := urlRepo.FindById(cl.ctx, e.UrlId)
+	if err != nil {
+		cl.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	//this content is the baseline or was already alerted on
+	if url.ContentAlertedHash == e.Hash || url.ContentHash == e.Hash {
+		return
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients: []string{url.ContactEmail},
+		Subject:    "Your Site's content has changed",
+		Content: fmt.Sprintf("The content of `%v` changed from the accepted baseline at %v.\nIf this change is expected, accept it with: accept_content %d",
+			url.DisplayUrl(), time.Now(), url.Id),
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		cl.logger.Error("Error sending content change email: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	err = urlRepo.UpdateContentAlertedHash(cl.ctx, e.UrlId, e.Hash)
+	if err != nil {
+		cl.logger.Error("Unable to update content alerted hash: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewContentChangedListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *ContentChangedListener {
+	return &ContentChangedListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
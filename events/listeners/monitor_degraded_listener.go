@@ -0,0 +1,69 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+// MonitorDegradedListener tells the owner when a site that is up becomes degraded, e.g. by responding
+// slower than its latency threshold or by a WARNING plugin, and when it is healthy again.
+type MonitorDegradedListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (ml *MonitorDegradedListener) Handle(event core.Event) {
+	e := event.(*events.StatusChanged)
+
+	//a first healthy check is not news, only the end of a slow period is
+	if e.To == enums.Healthy && e.From != enums.Degraded {
+		return
+	}
+	fmt.Printf("%v is %v, sending email out \n", e.Url, e.To)
+
+	urlRepo := database.NewUrlRepository(ml.DB)
+	url, err := urlRepo.FindById(ml.ctx, e.UrlId)
+	if err != nil {
+		ml.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	subject := "Your Site is DEGRADED"
+	content := fmt.Sprintf("Your Site `%v` is DEGRADED since %v.", url.DisplayUrl(), time.Now())
+	if e.DegradedReason != "" {
+		content += fmt.Sprintf("\nReason: %v", e.DegradedReason)
+	}
+	if e.To == enums.Healthy {
+		subject = "Your Site is no longer degraded"
+		content = fmt.Sprintf("Your Site `%v` is healthy again since %v.", url.DisplayUrl(), time.Now())
+	}
+	if e.Output != "" {
+		content += fmt.Sprintf("\nOutput: %v", e.Output)
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     subject,
+		Content:     content,
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		ml.logger.Error("Error sending monitoring alert email: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewMonitorDegradedListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *MonitorDegradedListener {
+	return &MonitorDegradedListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
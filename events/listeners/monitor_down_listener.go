@@ -0,0 +1,100 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+type MonitorDownListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (ml *MonitorDownListener) Handle(event core.Event) {
+	e := event.(*events.StatusChanged)
+	fmt.Printf("%v went down, sending email out \n", e.Url)
+
+	//a monitor whose checks never passed was never up, so there is nothing to report. A resumed
+	//monitor starts out pending again, but if it was up before the pause its outage still counts.
+	if !e.From.IsUp() && !ml.wasUp(e.UrlId) {
+		return
+	}
+
+	urlRepo := database.NewUrlRepository(ml.DB)
+	url, err := urlRepo.FindById(ml.ctx, e.UrlId)
+	if err != nil {
+		ml.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	incidentRepo := database.NewIncidentRepository(ml.DB)
+	err = incidentRepo.Add(ml.ctx, database.Incident{
+		UrlId:      url.Id,
+		FailedStep: e.FailedStep,
+	})
+	if err != nil {
+		ml.logger.Error("Unable to log incident: "+err.Error(), "url_id", e.UrlId)
+	}
+
+	//the incident is still logged while flapping, only the email is left out
+	if e.Suppressed {
+		return
+	}
+
+	content := fmt.Sprintf("Your Site `%v` is DOWN. It went down at %v\n . Please check it out", url.DisplayUrl(), time.Now())
+	if e.FailureCode != "" {
+		content += fmt.Sprintf("\nFailure: %v", e.FailureCode)
+	}
+	if e.FailedStep != "" {
+		content += fmt.Sprintf("\nFailed step: %v", e.FailedStep)
+	}
+	if e.FailureReason != "" {
+		content += fmt.Sprintf("\nReason: %v", e.FailureReason)
+	}
+	if e.FailedAssertion != "" {
+		content += fmt.Sprintf("\nFailed assertion: %v", e.FailedAssertion)
+	}
+	if e.Output != "" {
+		content += fmt.Sprintf("\nOutput: %v", e.Output)
+	}
+	if e.PerfData != "" {
+		content += fmt.Sprintf("\nPerfdata: %v", e.PerfData)
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     "Your Site is DOWN",
+		Content:     content,
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		ml.logger.Error("Error sending monitoring alert email: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+// wasUp reports whether any check of the url has ever passed
+func (ml *MonitorDownListener) wasUp(urlId int) bool {
+	_, err := database.NewUrlStatusRepository(ml.DB).GetRecentStatus(ml.ctx, urlId, enums.Healthy, enums.Degraded)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		ml.logger.Error("Unable to fetch url status: "+err.Error(), "url_id", urlId)
+	}
+	return err == nil
+}
+
+func NewMonitorDownListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *MonitorDownListener {
+	return &MonitorDownListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
@@ -0,0 +1,78 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+type MonitorFlappingListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (ml *MonitorFlappingListener) Handle(event core.Event) {
+	e := event.(*events.MonitorFlapping)
+	fmt.Printf("%v flapping: %v, sending email out \n", e.Url, e.Flapping)
+
+	urlRepo := database.NewUrlRepository(ml.DB)
+	url, err := urlRepo.FindById(ml.ctx, e.UrlId)
+	if err != nil {
+		ml.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	//flapping_since already records the start or the end of this episode
+	if e.Flapping == (url.FlappingSince != nil) {
+		return
+	}
+
+	current := "DOWN"
+	if e.Healthy {
+		current = "UP"
+	}
+
+	var since *time.Time
+	var subject, content string
+	if e.Flapping {
+		now := time.Now()
+		since = &now
+		subject = "Your Site is flapping"
+		content = fmt.Sprintf("Your Site `%v` changed status %d times in the last %v and is currently %v.\nUp and down alerts are suppressed until its status has been stable for %v.",
+			url.DisplayUrl(), e.Changes, e.Window, current, e.Window)
+	} else {
+		subject = "Your Site has stopped flapping"
+		content = fmt.Sprintf("Your Site `%v` has been stable for %v after flapping since %v and is currently %v.\nUp and down alerts are sent again.",
+			url.DisplayUrl(), e.Window, url.FlappingSince.Format(time.RFC1123), current)
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     subject,
+		Content:     content,
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		ml.logger.Error("Error sending flapping email: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	err = urlRepo.UpdateFlappingSince(ml.ctx, e.UrlId, since)
+	if err != nil {
+		ml.logger.Error("Unable to update flapping state: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewMonitorFlappingListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *MonitorFlappingListener {
+	return &MonitorFlappingListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
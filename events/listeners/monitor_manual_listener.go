@@ -0,0 +1,71 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+// MonitorManualListener handles the transitions only the owner makes: pausing a monitor, putting it
+// into maintenance and resuming it.
+type MonitorManualListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (ml *MonitorManualListener) Handle(event core.Event) {
+	e := event.(*events.StatusChanged)
+	fmt.Printf("%v changed from %v to %v, sending email out \n", e.Url, e.From, e.To)
+
+	urlRepo := database.NewUrlRepository(ml.DB)
+	url, err := urlRepo.FindById(ml.ctx, e.UrlId)
+	if err != nil {
+		ml.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	//the outage is expected from now on, and checks decide afresh once the url is resumed
+	if e.From == enums.UnHealthy {
+		err = database.NewIncidentRepository(ml.DB).Resolve(ml.ctx, url.Id)
+		if err != nil {
+			ml.logger.Error("Unable to log incident as resolved: "+err.Error(), "url_id", e.UrlId)
+		}
+	}
+
+	var subject, content string
+	switch e.To {
+	case enums.Paused:
+		subject = "Monitoring of your Site is paused"
+		content = fmt.Sprintf("Your Site `%v` is no longer checked until it is resumed.", url.DisplayUrl())
+	case enums.Maintenance:
+		subject = "Your Site is in maintenance"
+		content = fmt.Sprintf("Your Site `%v` is still checked, but no alerts are sent until it is resumed.", url.DisplayUrl())
+	default:
+		subject = "Monitoring of your Site is resumed"
+		content = fmt.Sprintf("Your Site `%v` is checked and alerted on again.", url.DisplayUrl())
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     subject,
+		Content:     content,
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		ml.logger.Error("Error sending status change email: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewMonitorManualListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *MonitorManualListener {
+	return &MonitorManualListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
@@ -0,0 +1,64 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/events"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+type MonitorUpListener struct {
+	ctx    context.Context
+	logger *slog.Logger
+	DB     *pgxpool.Pool
+}
+
+func (ml *MonitorUpListener) Handle(event core.Event) {
+	e := event.(*events.StatusChanged)
+	fmt.Printf("%v is back up, sending email out \n", e.Url)
+
+	urlRepo := database.NewUrlRepository(ml.DB)
+	url, err := urlRepo.FindById(ml.ctx, e.UrlId)
+	if err != nil {
+		ml.logger.Error("Error finding url: "+err.Error(), "url_id", e.UrlId)
+		return
+	}
+
+	incidentRepo := database.NewIncidentRepository(ml.DB)
+	err = incidentRepo.Resolve(ml.ctx, url.Id)
+	if err != nil {
+		ml.logger.Error("Unable to log incident as resolved: "+err.Error(), "url_id", e.UrlId)
+	}
+
+	//the incident is still resolved while flapping, only the email is left out
+	if e.Suppressed {
+		return
+	}
+
+	content := fmt.Sprintf("Your Site `%v` is UP. It went up at %v. Good work", url.DisplayUrl(), time.Now())
+	if e.Output != "" {
+		content += fmt.Sprintf("\nOutput: %v", e.Output)
+	}
+
+	err = core.SendEmail(core.SendEmailConfig{
+		Recipients:  []string{url.ContactEmail},
+		Subject:     "Your Site is now UP",
+		Content:     content,
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		ml.logger.Error("Error sending monitoring alert email: "+err.Error(), "url_id", e.UrlId)
+	}
+}
+
+func NewMonitorUpListener(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool) *MonitorUpListener {
+	return &MonitorUpListener{
+		logger: logger,
+		ctx:    ctx,
+		DB:     db,
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/horlerdipo/watchdog/events"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
-	"time"
 )
 
 type PingSuccessfulListener struct {
@@ -21,43 +20,33 @@ type PingSuccessfulListener struct {
 func (sl *PingSuccessfulListener) Handle(event core.Event) {
 	e := event.(*events.PingSuccessful)
 	fmt.Printf("%v is healthy, pushing to timescale DB \n", e.Url)
-	urlRepo := database.NewUrlRepository(sl.DB)
-	url, err := urlRepo.FindById(sl.ctx, e.UrlId)
-	if err != nil {
-		sl.logger.Error("Error finding url: ", err, e)
-		return
-	}
 
-	if url.Status == enums.UnHealthy {
-		incidentRepo := database.NewIncidentRepository(sl.DB)
-		err := incidentRepo.Resolve(sl.ctx, url.Id)
-		if err != nil {
-			sl.logger.Error("Unable to log incident as resolved: ", err.Error(), url)
-		}
-
-		err = core.SendEmail(core.SendEmailConfig{
-			Recipients:  []string{url.ContactEmail},
-			Subject:     "Your Site is now UP",
-			Content:     fmt.Sprintf("Your Site `%v` is UP. It went up at %v. Good work", url.Url, time.Now()),
-			ContentType: "text/plain",
-		})
-		if err != nil {
-			sl.logger.Error("Error sending monitoring alert email: ", err, e)
-		}
+	status := enums.Healthy
+	if e.Degraded {
+		status = enums.Degraded
 	}
 
 	urlStatusRepo := database.NewUrlStatusRepository(sl.DB)
-	err = urlStatusRepo.Add(sl.ctx, e.UrlId, e.Healthy)
+	err := urlStatusRepo.Add(sl.ctx, database.UrlStatus{
+		UrlId:       e.UrlId,
+		Status:      status,
+		LatencyMs:   e.Latency.Milliseconds(),
+		Steps:       e.Steps,
+		Redirects:   e.Redirects,
+		Output:      e.Output,
+		PerfData:    e.PerfData,
+		ContentHash: e.ContentHash,
+	})
 	if err != nil {
 		sl.logger.Error(err.Error(), e)
 		return
 	}
 
-	urlRepository := database.NewUrlRepository(sl.DB)
-	err = urlRepository.UpdateStatus(sl.ctx, e.UrlId, enums.Healthy)
-	if err != nil {
-		sl.logger.Error(err.Error(), e)
-		return
+	if !e.CertificateExpiresAt.IsZero() {
+		err = database.NewUrlRepository(sl.DB).UpdateCertificateExpiry(sl.ctx, e.UrlId, e.CertificateExpiresAt)
+		if err != nil {
+			sl.logger.Error("Unable to update certificate expiry: "+err.Error(), "url_id", e.UrlId)
+		}
 	}
 }
 
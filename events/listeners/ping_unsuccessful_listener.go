@@ -9,7 +9,6 @@ import (
 	"github.com/horlerdipo/watchdog/events"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
-	"time"
 )
 
 type PingUnSuccessfulListener struct {
@@ -20,46 +19,42 @@ type PingUnSuccessfulListener struct {
 
 func (sl *PingUnSuccessfulListener) Handle(event core.Event) {
 	e := event.(*events.PingUnSuccessful)
-	fmt.Printf("%v is unhealthy, pushing to timescale DB and sending email out \n", e.Url)
+	fmt.Printf("%v is unhealthy, pushing to timescale DB \n", e.Url)
 
-	urlRepo := database.NewUrlRepository(sl.DB)
-	url, err := urlRepo.FindById(sl.ctx, e.UrlId)
-	if err != nil {
-		sl.logger.Error("Error finding url: ", err, e)
-		return
+	failureReason := e.FailureReason
+	if failureReason == "" {
+		failureReason = e.FailedAssertion
 	}
 
-	//check if the previous status is healthy, if it is healthy, send email
-	if url.Status == enums.Healthy {
-		incidentRepo := database.NewIncidentRepository(sl.DB)
-		err := incidentRepo.Add(sl.ctx, url.Id)
-		if err != nil {
-			sl.logger.Error("Unable to log incident: ", err.Error(), url)
-		}
-
-		err = core.SendEmail(core.SendEmailConfig{
-			Recipients:  []string{url.ContactEmail},
-			Subject:     "Your Site is DOWN",
-			Content:     fmt.Sprintf("Your Site `%v` is DOWN. It went down at %v\n . Please check it out", url.Url, time.Now()),
-			ContentType: "text/plain",
-		})
-		if err != nil {
-			sl.logger.Error("Error sending monitoring alert email: ", err, e)
-		}
+	//an UNKNOWN plugin result says nothing about the site, so it is not stored as a failure
+	status := enums.UnHealthy
+	if e.FailureCode == enums.UnknownResult {
+		status = enums.Unknown
 	}
 
-	urlRepository := database.NewUrlRepository(sl.DB)
-	err = urlRepository.UpdateStatus(sl.ctx, e.UrlId, enums.UnHealthy)
+	urlStatusRepo := database.NewUrlStatusRepository(sl.DB)
+	err := urlStatusRepo.Add(sl.ctx, database.UrlStatus{
+		UrlId:         e.UrlId,
+		Status:        status,
+		LatencyMs:     e.Latency.Milliseconds(),
+		Steps:         e.Steps,
+		Redirects:     e.Redirects,
+		Output:        e.Output,
+		PerfData:      e.PerfData,
+		ContentHash:   e.ContentHash,
+		FailureCode:   e.FailureCode,
+		FailureReason: failureReason,
+	})
 	if err != nil {
 		sl.logger.Error(err.Error(), e)
 		return
 	}
 
-	urlStatusRepo := database.NewUrlStatusRepository(sl.DB)
-	err = urlStatusRepo.Add(sl.ctx, e.UrlId, e.Healthy)
-	if err != nil {
-		sl.logger.Error(err.Error(), e)
-		return
+	if !e.CertificateExpiresAt.IsZero() {
+		err = database.NewUrlRepository(sl.DB).UpdateCertificateExpiry(sl.ctx, e.UrlId, e.CertificateExpiresAt)
+		if err != nil {
+			sl.logger.Error("Unable to update certificate expiry: "+err.Error(), "url_id", e.UrlId)
+		}
 	}
 }
 
@@ -0,0 +1,21 @@
+package events
+
+import "time"
+
+// MonitorFlapping is dispatched when a monitor starts flapping and again once it has stabilized
+type MonitorFlapping struct {
+	UrlId    int
+	Url      string
+	Flapping bool
+	Healthy  bool
+	Changes  int
+	Window   time.Duration
+}
+
+func (m *MonitorFlapping) Name() string {
+	return "monitor.flapping"
+}
+
+func (m *MonitorFlapping) OrderingKey() string {
+	return urlOrderingKey(m.UrlId)
+}
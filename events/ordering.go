@@ -0,0 +1,8 @@
+package events
+
+import "strconv"
+
+// urlOrderingKey makes the event bus handle the events of one URL in the order they were dispatched
+func urlOrderingKey(urlId int) string {
+	return "url:" + strconv.Itoa(urlId)
+}
@@ -1,11 +1,28 @@
 package events
 
+import (
+	"github.com/horlerdipo/watchdog/core"
+	"time"
+)
+
 type PingSuccessful struct {
-	UrlId   int
-	Healthy bool
-	Url     string
+	UrlId                int
+	Healthy              bool
+	Degraded             bool
+	Url                  string
+	Latency              time.Duration
+	Steps                []core.StepResult
+	Redirects            []string
+	Output               string
+	PerfData             string
+	ContentHash          string
+	CertificateExpiresAt time.Time
 }
 
 func (p *PingSuccessful) Name() string {
 	return "ping.successful"
 }
+
+func (p *PingSuccessful) OrderingKey() string {
+	return urlOrderingKey(p.UrlId)
+}
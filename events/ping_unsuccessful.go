@@ -1,11 +1,32 @@
 package events
 
+import (
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/enums"
+	"time"
+)
+
 type PingUnSuccessful struct {
-	UrlId   int
-	Healthy bool
-	Url     string
+	UrlId                int
+	Healthy              bool
+	Url                  string
+	Latency              time.Duration
+	FailedAssertion      string
+	FailureCode          enums.FailureCode
+	FailureReason        string
+	FailedStep           string
+	Steps                []core.StepResult
+	Redirects            []string
+	Output               string
+	PerfData             string
+	ContentHash          string
+	CertificateExpiresAt time.Time
 }
 
 func (p *PingUnSuccessful) Name() string {
 	return "ping.unsuccessful"
 }
+
+func (p *PingUnSuccessful) OrderingKey() string {
+	return urlOrderingKey(p.UrlId)
+}
@@ -0,0 +1,45 @@
+package events
+
+import "github.com/horlerdipo/watchdog/enums"
+
+// StatusChanged is dispatched whenever a monitor moves from one status to another, by its checks or by
+// its owner. Its name depends on the transition, so listeners subscribe to the transitions they care about.
+// Paused and maintenance are set by the owner and never left because of a check.
+type StatusChanged struct {
+	UrlId           int
+	Url             string
+	From            enums.SiteHealth
+	To              enums.SiteHealth
+	DegradedReason  string
+	FailedAssertion string
+	FailureCode     enums.FailureCode
+	FailureReason   string
+	FailedStep      string
+	Output          string
+	PerfData        string
+	//Suppressed is set while the monitor is flapping, the monitor.flapping summary then replaces the up and down emails
+	Suppressed bool
+}
+
+func (s *StatusChanged) Name() string {
+	switch {
+	case s.To == enums.Paused:
+		return "monitor.paused"
+	case s.To == enums.Maintenance:
+		return "monitor.maintenance"
+	case s.From.IsManual():
+		return "monitor.resumed"
+	case s.To == enums.UnHealthy:
+		return "monitor.down"
+	case s.From == enums.UnHealthy:
+		return "monitor.up"
+	case s.To == enums.Degraded:
+		return "monitor.degraded"
+	default:
+		return "monitor.healthy"
+	}
+}
+
+func (s *StatusChanged) OrderingKey() string {
+	return urlOrderingKey(s.UrlId)
+}
@@ -0,0 +1,98 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Receiver is the HTTP endpoint push monitors check in to:
+// /heartbeat/{token}, /heartbeat/{token}/start, /heartbeat/{token}/success and /heartbeat/{token}/fail.
+type Receiver struct {
+	ctx           context.Context
+	Address       string
+	RedisClient   *redis.Client
+	UrlRepository database.UrlRepository
+	Logger        *slog.Logger
+}
+
+func (rc *Receiver) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/heartbeat/{token}", rc.handle)
+	mux.HandleFunc("/heartbeat/{token}/{action}", rc.handle)
+
+	server := &http.Server{
+		Addr:              rc.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-rc.ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		fmt.Printf("Heartbeat receiver listening on %v\n", rc.Address)
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			rc.Logger.Error("Heartbeat receiver stopped: " + err.Error())
+		}
+	}()
+}
+
+func (rc *Receiver) handle(w http.ResponseWriter, r *http.Request) {
+	url, err := rc.UrlRepository.FindByHeartbeatToken(r.Context(), r.PathValue("token"))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "unknown heartbeat", http.StatusNotFound)
+			return
+		}
+		rc.Logger.Error("Error finding heartbeat monitor: " + err.Error())
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "start":
+		err = core.StartHeartbeat(r.Context(), rc.RedisClient, url.Id)
+	case "", "success":
+		err = core.RecordHeartbeat(r.Context(), rc.RedisClient, url.Id, false)
+	case "fail":
+		err = core.RecordHeartbeat(r.Context(), rc.RedisClient, url.Id, true)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		rc.Logger.Error("Error recording heartbeat: "+err.Error(), "url_id", url.Id)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+func NewReceiver(ctx context.Context, address string, redisClient *redis.Client, urlRepository database.UrlRepository, logger *slog.Logger) *Receiver {
+	return &Receiver{
+		ctx:           ctx,
+		Address:       address,
+		RedisClient:   redisClient,
+		UrlRepository: urlRepository,
+		Logger:        logger,
+	}
+}
+
+// PingUrl is the address a job should call to check in.
+func PingUrl(baseUrl string, token string) string {
+	return fmt.Sprintf("%s/heartbeat/%s", baseUrl, token)
+}
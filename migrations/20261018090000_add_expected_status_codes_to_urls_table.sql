@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN expected_status_codes VARCHAR(255) NOT NULL DEFAULT '2xx';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN expected_status_codes;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls
+    ADD COLUMN headers      JSONB        NOT NULL DEFAULT '{}',
+    ADD COLUMN query_params JSONB        NOT NULL DEFAULT '{}',
+    ADD COLUMN request_body TEXT         NOT NULL DEFAULT '',
+    ADD COLUMN content_type VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls
+    DROP COLUMN headers,
+    DROP COLUMN query_params,
+    DROP COLUMN request_body,
+    DROP COLUMN content_type;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url_statuses ADD COLUMN latency_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN latency_threshold_ms INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE url_statuses DROP COLUMN latency_ms;
+ALTER TABLE urls DROP COLUMN latency_threshold_ms;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls
+    ADD COLUMN certificate_expires_at        TIMESTAMPTZ DEFAULT NULL,
+    ADD COLUMN certificate_alerted_threshold INTEGER     NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls
+    DROP COLUMN certificate_expires_at,
+    DROP COLUMN certificate_alerted_threshold;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls
+    ADD COLUMN monitor_type VARCHAR(255) NOT NULL DEFAULT 'http',
+    ADD COLUMN options      JSONB        NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls
+    DROP COLUMN monitor_type,
+    DROP COLUMN options;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN heartbeat_token VARCHAR(64) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX urls_heartbeat_token_unique ON urls (heartbeat_token) WHERE heartbeat_token <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX urls_heartbeat_token_unique;
+ALTER TABLE urls DROP COLUMN heartbeat_token;
+-- +goose StatementEnd
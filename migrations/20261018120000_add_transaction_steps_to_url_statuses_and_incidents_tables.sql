@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url_statuses ADD COLUMN steps JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE incidents ADD COLUMN failed_step VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE url_statuses DROP COLUMN steps;
+ALTER TABLE incidents DROP COLUMN failed_step;
+-- +goose StatementEnd
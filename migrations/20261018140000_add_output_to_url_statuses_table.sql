@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url_statuses
+    ADD COLUMN output    TEXT NOT NULL DEFAULT '',
+    ADD COLUMN perf_data TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE url_statuses
+    DROP COLUMN output,
+    DROP COLUMN perf_data;
+-- +goose StatementEnd
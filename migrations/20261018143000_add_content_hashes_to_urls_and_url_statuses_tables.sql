@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls
+    ADD COLUMN content_hash         VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN content_alerted_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE url_statuses ADD COLUMN content_hash VARCHAR(64) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls
+    DROP COLUMN content_hash,
+    DROP COLUMN content_alerted_hash;
+ALTER TABLE url_statuses DROP COLUMN content_hash;
+-- +goose StatementEnd
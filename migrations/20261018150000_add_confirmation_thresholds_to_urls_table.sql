@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN failure_threshold INT NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN recovery_threshold INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN failure_threshold;
+ALTER TABLE urls DROP COLUMN recovery_threshold;
+-- +goose StatementEnd
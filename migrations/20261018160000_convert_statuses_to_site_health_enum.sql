@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE site_health AS ENUM ('pending', 'healthy', 'degraded', 'unhealthy', 'paused', 'maintenance');
+
+ALTER TABLE urls ALTER COLUMN status TYPE site_health USING status::site_health;
+
+ALTER TABLE url_statuses ALTER COLUMN status TYPE site_health
+    USING (CASE WHEN status THEN 'healthy' ELSE 'unhealthy' END)::site_health;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE url_statuses ALTER COLUMN status TYPE BOOLEAN
+    USING status IN ('healthy', 'degraded');
+
+ALTER TABLE urls ALTER COLUMN status TYPE VARCHAR(255) USING status::text;
+
+DROP TYPE site_health;
+-- +goose StatementEnd
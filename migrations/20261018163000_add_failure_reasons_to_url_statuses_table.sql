@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url_statuses
+    ADD COLUMN failure_code   VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN failure_reason TEXT        NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE url_statuses
+    DROP COLUMN failure_code,
+    DROP COLUMN failure_reason;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE site_health ADD VALUE IF NOT EXISTS 'unknown';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE url_statuses SET status = 'unhealthy' WHERE status = 'unknown';
+
+ALTER TYPE site_health RENAME TO site_health_old;
+
+CREATE TYPE site_health AS ENUM ('pending', 'healthy', 'degraded', 'unhealthy', 'paused', 'maintenance');
+
+ALTER TABLE urls ALTER COLUMN status TYPE site_health USING status::text::site_health;
+
+ALTER TABLE url_statuses ALTER COLUMN status TYPE site_health USING status::text::site_health;
+
+DROP TYPE site_health_old;
+-- +goose StatementEnd
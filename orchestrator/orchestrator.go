@@ -8,12 +8,14 @@ import (
 	"github.com/horlerdipo/watchdog/enums"
 	"github.com/horlerdipo/watchdog/env"
 	"github.com/horlerdipo/watchdog/events/listeners"
+	"github.com/horlerdipo/watchdog/heartbeat"
 	"github.com/horlerdipo/watchdog/logger"
 	"github.com/horlerdipo/watchdog/supervisor"
 	"github.com/horlerdipo/watchdog/worker"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"log/slog"
+	"net/http"
 	"sync"
 	"time"
 )
@@ -29,6 +31,9 @@ type Orchestrator struct {
 	UrlRepository database.UrlRepository
 	Logger        *slog.Logger
 	EventBus      *core.EventBus
+	TokenCache    *worker.TokenCache
+	TLSCache      *worker.TLSCache
+	Receiver      *heartbeat.Receiver
 }
 
 func NewOrchestrator(ctx context.Context, rdC *redis.Client, pool *pgxpool.Pool) *Orchestrator {
@@ -36,6 +41,13 @@ func NewOrchestrator(ctx context.Context, rdC *redis.Client, pool *pgxpool.Pool)
 	newEventBus := core.NewEventBus(newLogger)
 	newEventBus.Subscribe("ping.successful", listeners.NewPingSuccessfulListener(ctx, newLogger, pool))
 	newEventBus.Subscribe("ping.unsuccessful", listeners.NewPingUnSuccessfulListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("monitor.down", listeners.NewMonitorDownListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("monitor.up", listeners.NewMonitorUpListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("monitor.degraded", listeners.NewMonitorDegradedListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("monitor.healthy", listeners.NewMonitorDegradedListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("certificate.expiring", listeners.NewCertificateExpiringListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("content.changed", listeners.NewContentChangedListener(ctx, newLogger, pool))
+	newEventBus.Subscribe("monitor.flapping", listeners.NewMonitorFlappingListener(ctx, newLogger, pool))
 
 	newSupervisor := supervisor.NewSupervisor(
 		ctx,
@@ -45,6 +57,18 @@ func NewOrchestrator(ctx context.Context, rdC *redis.Client, pool *pgxpool.Pool)
 		pool,
 	)
 
+	newTokenCache := worker.NewTokenCache(&http.Client{
+		Timeout: time.Duration(env.FetchInt("HTTP_REQUEST_TIMEOUT", 5)) * time.Second,
+	})
+
+	newReceiver := heartbeat.NewReceiver(
+		ctx,
+		env.FetchString("HEARTBEAT_ADDRESS", ":8080"),
+		rdC,
+		database.NewUrlRepository(pool),
+		newLogger,
+	)
+
 	return &Orchestrator{
 		intervals:     make(map[int]*worker.ParentWorker),
 		ctx:           ctx,
@@ -54,12 +78,16 @@ func NewOrchestrator(ctx context.Context, rdC *redis.Client, pool *pgxpool.Pool)
 		UrlRepository: database.NewUrlRepository(pool),
 		Logger:        newLogger,
 		EventBus:      &newEventBus,
+		TokenCache:    newTokenCache,
+		TLSCache:      worker.NewTLSCache(),
+		Receiver:      newReceiver,
 	}
 }
 
 func (o *Orchestrator) Start() {
 	fmt.Println("Orchestrator is running")
 	o.PrefillRedisList(o.ctx)
+	o.Receiver.Start()
 	for interval, parentWorker := range o.intervals {
 		ticker := time.NewTicker(time.Duration(interval) * time.Second)
 		o.waitGroup.Add(1)
@@ -89,7 +117,7 @@ func (o *Orchestrator) AddInterval(interval enums.MonitoringFrequency, worker *w
 
 func (o *Orchestrator) AddIntervals(intervals []enums.MonitoringFrequency) {
 	for _, interval := range intervals {
-		workerGroup := worker.NewParentWorker(o.ctx, o.RedisClient, interval.ToSeconds(), o.Supervisor)
+		workerGroup := worker.NewParentWorker(o.ctx, o.RedisClient, interval.ToSeconds(), o.Supervisor, o.TokenCache, o.TLSCache)
 		workerGroup.Start()
 		o.AddInterval(interval, workerGroup)
 	}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/env"
 	"github.com/horlerdipo/watchdog/events"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sync"
@@ -15,19 +18,79 @@ import (
 //Dispatches Event for when a URL is unreachable
 
 type Supervisor struct {
-	WorkPool  chan Task
-	BatchSize int
-	Timeout   time.Duration
-	ctx       context.Context
-	WaitGroup *sync.WaitGroup
-	EventBus  core.EventBus
-	DB        *pgxpool.Pool
+	WorkPool              chan Task
+	BatchSize             int
+	Timeout               time.Duration
+	ctx                   context.Context
+	WaitGroup             *sync.WaitGroup
+	EventBus              core.EventBus
+	DB                    *pgxpool.Pool
+	CertificateThresholds []int
+	FailureThreshold      int
+	RecoveryThreshold     int
+	FlapThreshold         int
+	FlapWindow            time.Duration
+	urls                  database.UrlRepository
+	streaks               map[int]streak
+	statuses              map[int]enums.SiteHealth
+	statusesLoadedFor     map[int]time.Time
+	//the alerts below are only remembered in memory, so after a restart the supervisor may announce
+	//them again. Their listeners compare each event with the state stored on the url before alerting
+	certificateAlerts map[int]certificateAlert
+	contentAlerts     map[int]string
+	flaps             map[int]flapState
+}
+
+// flapState remembers the status changes of a URL within the flap window
+type flapState struct {
+	changes  []time.Time
+	flapping bool
+}
+
+// streak counts the consecutive checks of a URL that returned the same result
+type streak struct {
+	healthy bool
+	count   int
+}
+
+// certificateAlert remembers the last expiry threshold announced for a URL's certificate
+type certificateAlert struct {
+	expiresAt time.Time
+	threshold int
 }
 
 type Task struct {
-	Healthy bool
-	Url     string
-	UrlId   int
+	Healthy  bool
+	Degraded bool
+	//DegradedReason explains why a healthy check is degraded, e.g. a slow response or a WARNING plugin
+	DegradedReason  string
+	Url             string
+	UrlId           int
+	Latency         time.Duration
+	FailedAssertion string
+	//FailureCode classifies the failure, FailureReason describes it
+	FailureCode   enums.FailureCode
+	FailureReason string
+	//FailedStep names the stage of a multi-stage check that failed, e.g. handshake or message
+	FailedStep string
+	//Steps holds per step results of transaction checks
+	Steps []core.StepResult
+	//Redirects holds the urls an HTTP check was redirected through, starting with the monitored url
+	Redirects []string
+	//Output and PerfData hold the first line of an exec check's stdout, split at the perfdata separator
+	Output   string
+	PerfData string
+	//ContentHash is the normalized body hash of checks with content change detection
+	ContentHash string
+	//CertificateExpiresAt is zero when the check was not made over TLS
+	CertificateExpiresAt time.Time
+	//FailureThreshold and RecoveryThreshold are the monitor's own settings, 0 falls back to the supervisor's
+	FailureThreshold  int
+	RecoveryThreshold int
+	//Status and StatusChangedAt are the monitor's status as last published to the workers. Only the owner
+	//publishes changes, so a new StatusChangedAt tells the supervisor to reload the status from Postgres
+	Status          enums.SiteHealth
+	StatusChangedAt time.Time
 }
 
 func (s *Supervisor) Activate() {
@@ -58,30 +121,292 @@ func (s *Supervisor) Activate() {
 func (s *Supervisor) flush(buffer []Task) {
 	for _, task := range buffer {
 		fmt.Printf("supervisor picked up new task %v\n", task.Url)
+		confirmed := s.confirm(task)
+		from := s.currentStatus(task)
+		to := nextStatus(from, task, confirmed)
+		suppressed := s.checkFlapping(task, from, to)
 		if task.Healthy {
 			s.EventBus.Dispatch(&events.PingSuccessful{
-				UrlId:   task.UrlId,
-				Healthy: task.Healthy,
-				Url:     task.Url,
+				UrlId:                task.UrlId,
+				Healthy:              task.Healthy,
+				Degraded:             task.Degraded,
+				Url:                  task.Url,
+				Latency:              task.Latency,
+				Steps:                task.Steps,
+				Redirects:            task.Redirects,
+				Output:               task.Output,
+				PerfData:             task.PerfData,
+				ContentHash:          task.ContentHash,
+				CertificateExpiresAt: task.CertificateExpiresAt,
 			})
 		} else {
 			s.EventBus.Dispatch(&events.PingUnSuccessful{
-				UrlId:   task.UrlId,
-				Healthy: task.Healthy,
-				Url:     task.Url,
+				UrlId:                task.UrlId,
+				Healthy:              task.Healthy,
+				Url:                  task.Url,
+				Latency:              task.Latency,
+				FailedAssertion:      task.FailedAssertion,
+				FailureCode:          task.FailureCode,
+				FailureReason:        task.FailureReason,
+				FailedStep:           task.FailedStep,
+				Steps:                task.Steps,
+				Redirects:            task.Redirects,
+				Output:               task.Output,
+				PerfData:             task.PerfData,
+				ContentHash:          task.ContentHash,
+				CertificateExpiresAt: task.CertificateExpiresAt,
 			})
 		}
+		if to != from {
+			s.transition(task, from, to, suppressed)
+		}
+		//the owner gets no alerts while a monitor is in maintenance
+		if !to.IsManual() {
+			s.checkCertificateExpiry(task)
+			s.checkContentChange(task)
+		}
+	}
+}
+
+// currentStatus returns the status of the URL before the task is applied. It is kept in memory and
+// loaded from Postgres when a URL is first seen, or when the owner paused, resumed or edited it. The
+// first load also restores a flapping episode recorded before a restart.
+func (s *Supervisor) currentStatus(task Task) enums.SiteHealth {
+	status, ok := s.statuses[task.UrlId]
+	if ok && s.statusesLoadedFor[task.UrlId].Equal(task.StatusChangedAt) {
+		return status
+	}
+
+	url, err := s.urls.FindById(s.ctx, task.UrlId)
+	if err != nil {
+		fmt.Printf("unable to fetch url status: %v\n", err)
+		return task.Status
+	}
+	s.statuses[task.UrlId] = url.Status
+	s.statusesLoadedFor[task.UrlId] = task.StatusChangedAt
+
+	//an episode that was still going on before a restart keeps its alerts suppressed for another
+	//window, so it still ends with the stop event that clears flapping_since
+	if _, ok := s.flaps[task.UrlId]; !ok && url.FlappingSince != nil {
+		s.flaps[task.UrlId] = flapState{changes: []time.Time{time.Now()}, flapping: true}
+	}
+	return url.Status
+}
+
+// nextStatus decides the status of a URL after a check
+func nextStatus(from enums.SiteHealth, task Task, confirmed bool) enums.SiteHealth {
+	switch {
+	case from.IsManual():
+		return from
+	case !task.Healthy:
+		//failures that have not been confirmed by consecutive checks are only recorded
+		if confirmed {
+			return enums.UnHealthy
+		}
+		return from
+	case from == enums.UnHealthy && !confirmed:
+		//a down site only comes back up once the recovery has been confirmed by consecutive checks
+		return from
+	case task.Degraded:
+		return enums.Degraded
+	default:
+		return enums.Healthy
+	}
+}
+
+// transition persists a status change and announces it. The update only applies while the status
+// is still from, so a pause or maintenance set by the owner in the meantime is never overwritten.
+func (s *Supervisor) transition(task Task, from enums.SiteHealth, to enums.SiteHealth, suppressed bool) {
+	changed, err := s.urls.TransitionStatus(s.ctx, task.UrlId, from, to)
+	if err != nil {
+		fmt.Printf("unable to update url status: %v\n", err)
+		return
+	}
+	if !changed {
+		delete(s.statuses, task.UrlId)
+		return
+	}
+	s.statuses[task.UrlId] = to
+
+	s.EventBus.Dispatch(&events.StatusChanged{
+		UrlId:           task.UrlId,
+		Url:             task.Url,
+		From:            from,
+		To:              to,
+		DegradedReason:  task.DegradedReason,
+		FailedAssertion: task.FailedAssertion,
+		FailureCode:     task.FailureCode,
+		FailureReason:   task.FailureReason,
+		FailedStep:      task.FailedStep,
+		Output:          task.Output,
+		PerfData:        task.PerfData,
+		Suppressed:      suppressed,
+	})
+}
+
+// confirm reports whether the task's result has been seen on enough consecutive checks
+// to change the URL's status, so a single blip neither takes a site down nor brings it back up.
+func (s *Supervisor) confirm(task Task) bool {
+	//an unknown result says nothing about the service, so it neither extends nor breaks a streak
+	if task.FailureCode == enums.UnknownResult {
+		return false
+	}
+
+	current := s.streaks[task.UrlId]
+	if current.healthy != task.Healthy || current.count == 0 {
+		current = streak{healthy: task.Healthy}
+	}
+	current.count++
+	s.streaks[task.UrlId] = current
+
+	threshold := task.FailureThreshold
+	fallback := s.FailureThreshold
+	if task.Healthy {
+		threshold = task.RecoveryThreshold
+		fallback = s.RecoveryThreshold
+	}
+	if threshold <= 0 {
+		threshold = fallback
+	}
+	return current.count >= threshold
+}
+
+// checkFlapping counts the changes of a URL between up and down within the flap window and reports
+// whether its alerts should be suppressed. A single monitor.flapping event announces the start
+// of an episode and another one its end, once no change happened for a whole window.
+func (s *Supervisor) checkFlapping(task Task, from enums.SiteHealth, to enums.SiteHealth) bool {
+	state := s.flaps[task.UrlId]
+	if s.FlapThreshold <= 0 || to.IsManual() {
+		return state.flapping
+	}
+
+	now := time.Now()
+	if from.IsUp() && to == enums.UnHealthy || from == enums.UnHealthy && to.IsUp() {
+		state.changes = append(state.changes, now)
+	}
+
+	recent := state.changes[:0]
+	for _, changedAt := range state.changes {
+		if now.Sub(changedAt) < s.FlapWindow {
+			recent = append(recent, changedAt)
+		}
+	}
+	state.changes = recent
+
+	switch {
+	case !state.flapping && len(state.changes) >= s.FlapThreshold:
+		state.flapping = true
+	case state.flapping && len(state.changes) == 0:
+		state.flapping = false
+	default:
+		s.flaps[task.UrlId] = state
+		return state.flapping
+	}
+	s.flaps[task.UrlId] = state
+
+	s.EventBus.Dispatch(&events.MonitorFlapping{
+		UrlId:    task.UrlId,
+		Url:      task.Url,
+		Flapping: state.flapping,
+		Healthy:  to.IsUp(),
+		Changes:  len(state.changes),
+		Window:   s.FlapWindow,
+	})
+	//the event replaces the alert of the change that started or ended the episode
+	return true
+}
+
+func (s *Supervisor) checkCertificateExpiry(task Task) {
+	if task.CertificateExpiresAt.IsZero() {
+		return
+	}
+
+	daysRemaining := int(time.Until(task.CertificateExpiresAt).Hours() / 24)
+	threshold := 0
+	for _, days := range s.CertificateThresholds {
+		if daysRemaining <= days && (threshold == 0 || days < threshold) {
+			threshold = days
+		}
+	}
+	if threshold == 0 {
+		return
 	}
+
+	previous, ok := s.certificateAlerts[task.UrlId]
+	if ok && previous.expiresAt.Equal(task.CertificateExpiresAt) && previous.threshold <= threshold {
+		return
+	}
+	s.certificateAlerts[task.UrlId] = certificateAlert{
+		expiresAt: task.CertificateExpiresAt,
+		threshold: threshold,
+	}
+
+	s.EventBus.Dispatch(&events.CertificateExpiring{
+		UrlId:         task.UrlId,
+		Url:           task.Url,
+		ExpiresAt:     task.CertificateExpiresAt,
+		DaysRemaining: daysRemaining,
+		Threshold:     threshold,
+	})
+}
+
+func (s *Supervisor) checkContentChange(task Task) {
+	if task.ContentHash == "" {
+		return
+	}
+
+	//the baseline lives in Postgres because the accept command changes it from another process
+	url, err := s.urls.FindById(s.ctx, task.UrlId)
+	if err != nil {
+		fmt.Printf("unable to fetch content baseline: %v\n", err)
+		return
+	}
+
+	if url.ContentHash == "" {
+		err = s.urls.UpdateContentBaseline(s.ctx, url.Id, task.ContentHash)
+		if err != nil {
+			fmt.Printf("unable to store content baseline: %v\n", err)
+		}
+		return
+	}
+
+	if url.ContentHash == task.ContentHash {
+		delete(s.contentAlerts, task.UrlId)
+		return
+	}
+	if s.contentAlerts[task.UrlId] == task.ContentHash {
+		return
+	}
+	s.contentAlerts[task.UrlId] = task.ContentHash
+
+	s.EventBus.Dispatch(&events.ContentChanged{
+		UrlId:        task.UrlId,
+		Url:          task.Url,
+		BaselineHash: url.ContentHash,
+		Hash:         task.ContentHash,
+	})
 }
 
 func NewSupervisor(ctx context.Context, batchSize int, Timeout time.Duration, eventBus core.EventBus, db *pgxpool.Pool) *Supervisor {
 	return &Supervisor{
-		WorkPool:  make(chan Task, batchSize),
-		ctx:       ctx,
-		BatchSize: batchSize,
-		Timeout:   Timeout,
-		WaitGroup: &sync.WaitGroup{},
-		EventBus:  eventBus,
-		DB:        db,
+		WorkPool:              make(chan Task, batchSize),
+		ctx:                   ctx,
+		BatchSize:             batchSize,
+		Timeout:               Timeout,
+		WaitGroup:             &sync.WaitGroup{},
+		EventBus:              eventBus,
+		DB:                    db,
+		urls:                  database.NewUrlRepository(db),
+		CertificateThresholds: env.FetchIntSlice("CERTIFICATE_EXPIRY_THRESHOLDS", []int{30, 14, 7, 1}),
+		FailureThreshold:      env.FetchInt("FAILURE_THRESHOLD", 1),
+		RecoveryThreshold:     env.FetchInt("RECOVERY_THRESHOLD", 1),
+		FlapThreshold:         env.FetchInt("FLAP_THRESHOLD", 5),
+		FlapWindow:            time.Duration(env.FetchInt("FLAP_WINDOW", 60)) * time.Minute,
+		certificateAlerts:     make(map[int]certificateAlert),
+		contentAlerts:         make(map[int]string),
+		streaks:               make(map[int]streak),
+		flaps:                 make(map[int]flapState),
+		statuses:              make(map[int]enums.SiteHealth),
+		statusesLoadedFor:     make(map[int]time.Time),
 	}
 }
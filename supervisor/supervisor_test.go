@@ -0,0 +1,171 @@
+package supervisor
+
+import (
+	"context"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/events"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// fakeUrlRepository keeps urls in memory and applies status changes the way Postgres does
+type fakeUrlRepository struct {
+	database.UrlRepository
+	urls map[int]database.Url
+}
+
+func (fr *fakeUrlRepository) FindById(ctx context.Context, id int) (database.Url, error) {
+	return fr.urls[id], nil
+}
+
+func (fr *fakeUrlRepository) TransitionStatus(ctx context.Context, id int, from enums.SiteHealth, to enums.SiteHealth) (bool, error) {
+	if fr.urls[id].Status != from {
+		return false, nil
+	}
+	fr.setStatus(id, to)
+	return true, nil
+}
+
+// setStatus changes the status like the pause, maintenance and resume commands do
+func (fr *fakeUrlRepository) setStatus(id int, status enums.SiteHealth) {
+	url := fr.urls[id]
+	url.Status = status
+	url.StatusChangedAt = url.StatusChangedAt.Add(time.Second)
+	fr.urls[id] = url
+}
+
+// taskFor returns the result of a check of a url whose details were just published to the workers
+func (fr *fakeUrlRepository) taskFor(id int, healthy bool) Task {
+	url := fr.urls[id]
+	return Task{
+		UrlId:           id,
+		Url:             url.Url,
+		Healthy:         healthy,
+		Status:          url.Status,
+		StatusChangedAt: url.StatusChangedAt,
+	}
+}
+
+type recordingEventBus struct {
+	events []core.Event
+}
+
+func (rb *recordingEventBus) Logger() *slog.Logger                                  { return slog.Default() }
+func (rb *recordingEventBus) Subscribe(eventName string, handler core.EventHandler) {}
+func (rb *recordingEventBus) Dispatch(event core.Event)                             { rb.events = append(rb.events, event) }
+func (rb *recordingEventBus) Wait()                                                 {}
+
+// statusChanges returns the names of the status transitions dispatched since the last call
+func (rb *recordingEventBus) statusChanges() []string {
+	var names []string
+	for _, event := range rb.events {
+		if statusChanged, ok := event.(*events.StatusChanged); ok {
+			names = append(names, statusChanged.Name())
+		}
+	}
+	rb.events = nil
+	return names
+}
+
+func newTestSupervisor(status enums.SiteHealth) (*Supervisor, *fakeUrlRepository, *recordingEventBus) {
+	repository := &fakeUrlRepository{urls: map[int]database.Url{
+		1: {Id: 1, Url: "https://example.com", Status: status, StatusChangedAt: time.Now()},
+	}}
+	eventBus := &recordingEventBus{}
+	supervisor := &Supervisor{
+		ctx:               context.Background(),
+		EventBus:          eventBus,
+		FailureThreshold:  1,
+		RecoveryThreshold: 1,
+		urls:              repository,
+		certificateAlerts: make(map[int]certificateAlert),
+		contentAlerts:     make(map[int]string),
+		streaks:           make(map[int]streak),
+		flaps:             make(map[int]flapState),
+		statuses:          make(map[int]enums.SiteHealth),
+		statusesLoadedFor: make(map[int]time.Time),
+	}
+	return supervisor, repository, eventBus
+}
+
+func TestResumeAfterPauseWhileHealthy(t *testing.T) {
+	supervisor, repository, eventBus := newTestSupervisor(enums.Healthy)
+	supervisor.flush([]Task{repository.taskFor(1, true)})
+	if changes := eventBus.statusChanges(); len(changes) != 0 {
+		t.Fatalf("expected no transition before the pause, got %v", changes)
+	}
+
+	//paused monitors are not checked, so the supervisor only sees the resumed one
+	repository.setStatus(1, enums.Paused)
+	repository.setStatus(1, enums.Pending)
+	supervisor.flush([]Task{repository.taskFor(1, true)})
+
+	if changes := eventBus.statusChanges(); len(changes) != 1 || changes[0] != "monitor.healthy" {
+		t.Fatalf("expected monitor.healthy after the resume, got %v", changes)
+	}
+	if status := repository.urls[1].Status; status != enums.Healthy {
+		t.Fatalf("expected the url to be healthy again, got %v", status)
+	}
+}
+
+func TestResumeAfterPauseWhileDown(t *testing.T) {
+	supervisor, repository, eventBus := newTestSupervisor(enums.Healthy)
+	supervisor.flush([]Task{repository.taskFor(1, false)})
+	if changes := eventBus.statusChanges(); len(changes) != 1 || changes[0] != "monitor.down" {
+		t.Fatalf("expected monitor.down before the pause, got %v", changes)
+	}
+
+	repository.setStatus(1, enums.Paused)
+	repository.setStatus(1, enums.Pending)
+	supervisor.flush([]Task{repository.taskFor(1, false)})
+
+	if changes := eventBus.statusChanges(); len(changes) != 1 || changes[0] != "monitor.down" {
+		t.Fatalf("expected monitor.down after the resume, got %v", changes)
+	}
+	if status := repository.urls[1].Status; status != enums.UnHealthy {
+		t.Fatalf("expected the url to be unhealthy again, got %v", status)
+	}
+}
+
+func TestNoAlertsDuringMaintenance(t *testing.T) {
+	supervisor, repository, eventBus := newTestSupervisor(enums.Maintenance)
+	supervisor.CertificateThresholds = []int{30}
+
+	task := repository.taskFor(1, true)
+	task.CertificateExpiresAt = time.Now().Add(24 * time.Hour)
+	supervisor.flush([]Task{task})
+
+	for _, event := range eventBus.events {
+		if _, ok := event.(*events.CertificateExpiring); ok {
+			t.Fatalf("expected no certificate alert during maintenance")
+		}
+	}
+	if changes := eventBus.statusChanges(); len(changes) != 0 {
+		t.Fatalf("expected the url to stay in maintenance, got %v", changes)
+	}
+}
+
+func TestDegradedReasonIsCarried(t *testing.T) {
+	supervisor, repository, eventBus := newTestSupervisor(enums.Healthy)
+
+	task := repository.taskFor(1, true)
+	task.Degraded = true
+	task.DegradedReason = "WARNING: disk at 85%"
+	supervisor.flush([]Task{task})
+
+	var statusChanged *events.StatusChanged
+	for _, event := range eventBus.events {
+		if changed, ok := event.(*events.StatusChanged); ok {
+			statusChanged = changed
+		}
+	}
+	if statusChanged == nil || statusChanged.Name() != "monitor.degraded" {
+		t.Fatalf("expected monitor.degraded, got %v", statusChanged)
+	}
+	if statusChanged.DegradedReason != task.DegradedReason {
+		t.Fatalf("expected reason %q, got %q", task.DegradedReason, statusChanged.DegradedReason)
+	}
+}
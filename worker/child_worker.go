@@ -5,9 +5,8 @@ import (
 	"fmt"
 	"github.com/horlerdipo/watchdog/core"
 	"github.com/horlerdipo/watchdog/database"
-	"github.com/horlerdipo/watchdog/env"
+	"github.com/horlerdipo/watchdog/enums"
 	"github.com/horlerdipo/watchdog/supervisor"
-	"net/http"
 	"time"
 )
 
@@ -49,9 +48,6 @@ func (cw *ChildWorker) Start() {
 }
 
 func (cw *ChildWorker) Work(urlId string) {
-	client := &http.Client{
-		Timeout: time.Duration(env.FetchInt("HTTP_REQUEST_TIMEOUT", 5)) * time.Second,
-	}
 	var url database.Url
 	val, err := cw.ParentWorker.RedisClient.HGet(cw.Ctx, core.FormatRedisHash(cw.ParentWorker.Interval), urlId).Bytes()
 	if err != nil {
@@ -64,31 +60,60 @@ func (cw *ChildWorker) Work(urlId string) {
 		return
 	}
 
-	request, err := http.NewRequest(url.HttpMethod.ToMethod(), url.Url, nil)
-	if err != nil {
-		fmt.Println(err)
+	//the pause and resume commands refresh the monitor details, so the status here is current
+	if url.Status == enums.Paused {
 		return
 	}
 
-	resp, err := client.Do(request)
+	var task supervisor.Task
+	switch url.Type {
+	case enums.Tcp:
+		task, err = cw.checkTcp(url)
+	case enums.Dns:
+		task, err = cw.checkDns(url)
+	case enums.Heartbeat:
+		task, err = cw.checkHeartbeat(url)
+	case enums.Grpc:
+		task, err = cw.checkGrpc(url)
+	case enums.WebSocket:
+		task, err = cw.checkWebSocket(url)
+	case enums.Transaction:
+		task, err = cw.checkTransaction(url)
+	case enums.Exec:
+		task, err = cw.checkExec(url)
+	case enums.Postgres:
+		task, err = cw.checkPostgres(url)
+	case enums.Mysql:
+		task, err = cw.checkMysql(url)
+	case enums.Redis:
+		task, err = cw.checkRedis(url)
+	case enums.Smtp, enums.Imap, enums.Pop3:
+		task, err = cw.checkMail(url)
+	default:
+		task, err = cw.checkHttp(url)
+	}
 	if err != nil {
-		fmt.Printf("client error: %v", err)
-		task := supervisor.Task{
-			Healthy: false,
-			Url:     url.Url,
-			UrlId:   url.Id,
-		}
-		cw.ParentWorker.Supervisor.WorkPool <- task
+		fmt.Println(err)
 		return
 	}
-	defer resp.Body.Close()
-	fmt.Printf("Worker %d with parent %v interval tried monitoring %v and returned %v \n", cw.Id, cw.ParentWorker.Interval, url, resp.StatusCode)
-	task := supervisor.Task{
-		UrlId:   url.Id,
-		Healthy: resp.StatusCode > 199 && resp.StatusCode < 300,
-		Url:     url.Url,
+	task.FailureThreshold = url.FailureThreshold
+	//a check that passes slower than the monitor allows is degraded, whatever its type
+	if task.Healthy && url.LatencyThresholdMs > 0 && task.Latency > time.Duration(url.LatencyThresholdMs)*time.Millisecond {
+		task.Degraded = true
+		if task.DegradedReason == "" {
+			task.DegradedReason = fmt.Sprintf("responded in %v, slower than its latency threshold of %vms", task.Latency.Round(time.Millisecond), url.LatencyThresholdMs)
+		}
+	}
+	//every failure carries a code, checks only set one when they know better than these defaults
+	if !task.Healthy && task.FailureCode == "" {
+		task.FailureCode = enums.CheckFailed
+		if task.FailedAssertion != "" {
+			task.FailureCode = enums.AssertionFailed
+		}
 	}
+	task.RecoveryThreshold = url.RecoveryThreshold
+	task.Status = url.Status
+	task.StatusChangedAt = url.StatusChangedAt
 
 	cw.ParentWorker.Supervisor.WorkPool <- task
-	return
 }
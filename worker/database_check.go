@@ -0,0 +1,298 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const postgresReplicationLagQuery = "SELECT CASE WHEN pg_is_in_recovery() THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) ELSE -1 END"
+
+func (cw *ChildWorker) checkPostgres(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	conn, err := pgx.Connect(ctx, url.Url)
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return databaseFailure(task, "connect", err), nil
+	}
+	defer conn.Close(cw.Ctx)
+
+	rows, err := conn.Query(ctx, databaseQuery(url, "SELECT 1"))
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return databaseFailure(task, "query", err), nil
+	}
+	var result string
+	if rows.Next() {
+		values, err := rows.Values()
+		if err == nil && len(values) > 0 {
+			result = fmt.Sprint(values[0])
+		}
+	}
+	rows.Close()
+	task.Latency = time.Since(startedAt)
+	if rows.Err() != nil {
+		return databaseFailure(task, "query", rows.Err()), nil
+	}
+
+	if !checkDatabaseResult(&task, url, result) || url.Options.MaxReplicationLagSeconds <= 0 {
+		return task, nil
+	}
+
+	var lag float64
+	err = conn.QueryRow(ctx, postgresReplicationLagQuery).Scan(&lag)
+	if err != nil {
+		return databaseFailure(task, "replication", err), nil
+	}
+	if lag < 0 {
+		return databaseFailure(task, "replication", assertionError{"server is not a replica"}), nil
+	}
+	checkReplicationLag(&task, url, lag)
+	return task, nil
+}
+
+func (cw *ChildWorker) checkMysql(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	db, err := sql.Open("mysql", url.Url)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return databaseFailure(task, "connect", err), nil
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, databaseQuery(url, "SELECT 1"))
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return databaseFailure(task, "query", err), nil
+	}
+	columns, row, err := firstSqlRow(rows)
+	task.Latency = time.Since(startedAt)
+	if err != nil {
+		return databaseFailure(task, "query", err), nil
+	}
+
+	var result string
+	if row != nil && len(columns) > 0 {
+		result = row[columns[0]]
+	}
+
+	if !checkDatabaseResult(&task, url, result) || url.Options.MaxReplicationLagSeconds <= 0 {
+		return task, nil
+	}
+
+	//MySQL 8.0.22 renamed the replica status columns, older servers only know the slave variants
+	rows, err = conn.QueryContext(ctx, "SHOW REPLICA STATUS")
+	lagColumn := "Seconds_Behind_Source"
+	if err != nil {
+		rows, err = conn.QueryContext(ctx, "SHOW SLAVE STATUS")
+		lagColumn = "Seconds_Behind_Master"
+	}
+	if err != nil {
+		return databaseFailure(task, "replication", err), nil
+	}
+	_, status, err := firstSqlRow(rows)
+	if err != nil {
+		return databaseFailure(task, "replication", err), nil
+	}
+	if status == nil {
+		return databaseFailure(task, "replication", assertionError{"server is not a replica"}), nil
+	}
+	lag, err := strconv.ParseFloat(status[lagColumn], 64)
+	if err != nil {
+		//Seconds_Behind_Source is NULL while replication is stopped
+		return databaseFailure(task, "replication", assertionError{"replication is not running"}), nil
+	}
+	checkReplicationLag(&task, url, lag)
+	return task, nil
+}
+
+func (cw *ChildWorker) checkRedis(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	options, err := redis.ParseURL(url.Url)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+	options.DialTimeout = timeout
+	options.MaxRetries = -1
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	//go-redis connects lazily, so a PING separates connection failures from query failures
+	startedAt := time.Now()
+	err = client.Ping(ctx).Err()
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return databaseFailure(task, "connect", err), nil
+	}
+
+	var args []interface{}
+	for _, field := range strings.Fields(databaseQuery(url, "PING")) {
+		args = append(args, field)
+	}
+	reply, err := client.Do(ctx, args...).Result()
+	task.Latency = time.Since(startedAt)
+	if err != nil && err != redis.Nil {
+		return databaseFailure(task, "query", err), nil
+	}
+
+	//a missing key is an empty result rather than a failure
+	var result string
+	if err == nil {
+		result = fmt.Sprint(reply)
+	}
+
+	if !checkDatabaseResult(&task, url, result) || url.Options.MaxReplicationLagSeconds <= 0 {
+		return task, nil
+	}
+
+	info, err := client.Info(ctx, "replication").Result()
+	if err != nil {
+		return databaseFailure(task, "replication", err), nil
+	}
+	replication := parseRedisInfo(info)
+	if replication["role"] != "slave" {
+		return databaseFailure(task, "replication", assertionError{"server is not a replica"}), nil
+	}
+	if replication["master_link_status"] != "up" {
+		return databaseFailure(task, "replication", assertionError{"link to the master is " + replication["master_link_status"]}), nil
+	}
+	lag, err := strconv.ParseFloat(replication["master_last_io_seconds_ago"], 64)
+	if err != nil {
+		return databaseFailure(task, "replication", fmt.Errorf("unable to read replication lag: %w", err)), nil
+	}
+	checkReplicationLag(&task, url, lag)
+	return task, nil
+}
+
+func databaseQuery(url database.Url, fallback string) string {
+	if url.Options.Query != "" {
+		return url.Options.Query
+	}
+	return fallback
+}
+
+func databaseFailure(task supervisor.Task, step string, err error) supervisor.Task {
+	fmt.Printf("%s error: %v", step, err)
+	task.Healthy = false
+	task.FailedStep = step
+	task.FailureCode = classifyError(err)
+	task.FailureReason = fmt.Sprintf("%s failed: %v", step, err)
+	return task
+}
+
+// checkDatabaseResult marks the task healthy when result matches the expected result, if any.
+func checkDatabaseResult(task *supervisor.Task, url database.Url, result string) bool {
+	if url.Options.Expect != "" && strings.TrimSpace(result) != url.Options.Expect {
+		task.Healthy = false
+		task.FailedStep = "query"
+		task.FailureCode = enums.AssertionFailed
+		task.FailureReason = fmt.Sprintf("expected result %q, received %q", url.Options.Expect, result)
+		return false
+	}
+	task.Healthy = true
+	return true
+}
+
+func checkReplicationLag(task *supervisor.Task, url database.Url, lag float64) {
+	if lag > float64(url.Options.MaxReplicationLagSeconds) {
+		task.Healthy = false
+		task.FailedStep = "replication"
+		task.FailureCode = enums.AssertionFailed
+		task.FailureReason = fmt.Sprintf("replication lag of %.0fs exceeds %ds", lag, url.Options.MaxReplicationLagSeconds)
+	}
+}
+
+// firstSqlRow reads the first row of rows as strings keyed by column name, or nil when there are none.
+// The column names are returned in order as well.
+func firstSqlRow(rows *sql.Rows) ([]string, map[string]string, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rows.Next() {
+		return columns, nil, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	destinations := make([]interface{}, len(columns))
+	for i := range values {
+		destinations[i] = &values[i]
+	}
+	if err := rows.Scan(destinations...); err != nil {
+		return nil, nil, err
+	}
+
+	row := make(map[string]string, len(columns))
+	for i, column := range columns {
+		row[column] = values[i].String
+	}
+	return columns, row, rows.Err()
+}
+
+func parseRedisInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\r\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
+// ValidateDsn reports whether dsn can be used by a database monitor of the given type.
+func ValidateDsn(monitorType enums.MonitorType, dsn string) error {
+	var err error
+	switch monitorType {
+	case enums.Postgres:
+		_, err = pgx.ParseConfig(dsn)
+	case enums.Mysql:
+		_, err = mysql.ParseDSN(dsn)
+	case enums.Redis:
+		_, err = redis.ParseURL(dsn)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (cw *ChildWorker) checkDns(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	answers, err := LookupDns(ctx, NewDnsResolver(url.Options.Resolver), DnsHost(url.Url), url.Options.RecordType)
+	task.Latency = time.Since(startedAt)
+	if err != nil {
+		fmt.Printf("dns error: %v", err)
+		task.FailureCode = enums.DnsResolutionFailed
+		task.FailureReason = fmt.Sprintf("unable to resolve %s record: %v", url.Options.RecordType, err)
+		return task, nil
+	}
+
+	if len(answers) == 0 {
+		task.FailureCode = enums.DnsResolutionFailed
+		task.FailureReason = fmt.Sprintf("no %s records found", url.Options.RecordType)
+		return task, nil
+	}
+
+	if len(url.Options.ExpectedAnswers) > 0 {
+		expected := normalizeDnsAnswers(url.Options.ExpectedAnswers, url.Options.RecordType)
+		if !slices.Equal(answers, expected) {
+			task.FailureCode = enums.AssertionFailed
+			task.FailureReason = fmt.Sprintf("%s records changed: expected [%s], got [%s]", url.Options.RecordType, strings.Join(expected, ", "), strings.Join(answers, ", "))
+			return task, nil
+		}
+	}
+
+	fmt.Printf("Worker %d with parent %v interval resolved %v to %v \n", cw.Id, cw.ParentWorker.Interval, url.Url, answers)
+	task.Healthy = true
+	return task, nil
+}
+
+// NewDnsResolver returns a resolver that sends every query to address,
+// or the system resolver when address is empty.
+func NewDnsResolver(address string) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// LookupDns returns the sorted, normalized answer set for host.
+func LookupDns(ctx context.Context, resolver *net.Resolver, host string, recordType enums.DnsRecordType) ([]string, error) {
+	var answers []string
+	switch recordType {
+	case enums.ARecord, enums.AAAARecord:
+		network := "ip4"
+		if recordType == enums.AAAARecord {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case enums.CNAMERecord:
+		cname, err := resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, cname)
+	case enums.MXRecord:
+		records, err := resolver.LookupMX(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			answers = append(answers, fmt.Sprintf("%d %s", record.Pref, record.Host))
+		}
+	case enums.TXTRecord:
+		records, err := resolver.LookupTXT(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, records...)
+	default:
+		return nil, fmt.Errorf("invalid dns record type: %s", recordType)
+	}
+	return normalizeDnsAnswers(answers, recordType), nil
+}
+
+// DnsHost strips an optional dns:// scheme from a monitor's target.
+func DnsHost(target string) string {
+	return strings.TrimPrefix(target, "dns://")
+}
+
+func normalizeDnsAnswers(answers []string, recordType enums.DnsRecordType) []string {
+	normalized := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		answer = strings.TrimSpace(answer)
+		//TXT contents are case sensitive, host names are not
+		if recordType != enums.TXTRecord {
+			answer = strings.ToLower(strings.TrimSuffix(answer, "."))
+		}
+		normalized = append(normalized, answer)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
@@ -0,0 +1,183 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"golang.org/x/net/dns/dnsmessage"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testDnsServer answers A, MX and TXT queries for a fixed zone over UDP, replies NXDOMAIN for
+// every other name and never answers queries for silent.watchdog.test.
+type testDnsServer struct {
+	conn *net.UDPConn
+}
+
+func startTestDnsServer(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("unable to start dns server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	server := &testDnsServer{conn: conn}
+	go server.serve()
+	return conn.LocalAddr().String()
+}
+
+func (ts *testDnsServer) serve() {
+	buffer := make([]byte, 512)
+	for {
+		n, addr, err := ts.conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		reply, ok := ts.answer(buffer[:n])
+		if ok {
+			ts.conn.WriteToUDP(reply, addr)
+		}
+	}
+}
+
+func (ts *testDnsServer) answer(query []byte) ([]byte, bool) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, false
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil, false
+	}
+
+	name := question.Name.String()
+	if name == "silent.watchdog.test." {
+		return nil, false
+	}
+
+	header = dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true}
+	//the name exists when it only has no records of the asked type
+	if name != "www.watchdog.test." && name != "watchdog.test." {
+		header.RCode = dnsmessage.RCodeNameError
+	}
+
+	builder := dnsmessage.NewBuilder(nil, header)
+	builder.EnableCompression()
+	builder.StartQuestions()
+	builder.Question(question)
+	builder.StartAnswers()
+
+	resource := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+	switch {
+	case name == "www.watchdog.test." && question.Type == dnsmessage.TypeA:
+		builder.AResource(resource, dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}})
+		builder.AResource(resource, dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+	case name == "watchdog.test." && question.Type == dnsmessage.TypeMX:
+		builder.MXResource(resource, dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("Mail.Watchdog.Test.")})
+	case name == "watchdog.test." && question.Type == dnsmessage.TypeTXT:
+		builder.TXTResource(resource, dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}})
+	}
+
+	reply, err := builder.Finish()
+	return reply, err == nil
+}
+
+func TestLookupDns(t *testing.T) {
+	resolver := NewDnsResolver(startTestDnsServer(t))
+
+	tests := []struct {
+		name       string
+		host       string
+		recordType enums.DnsRecordType
+		expected   []string
+	}{
+		{"sorted a records", "www.watchdog.test.", enums.ARecord, []string{"192.0.2.1", "192.0.2.10"}},
+		{"normalized mx records", "watchdog.test.", enums.MXRecord, []string{"10 mail.watchdog.test"}},
+		{"case sensitive txt records", "watchdog.test.", enums.TXTRecord, []string{"v=spf1 -all"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			answers, err := LookupDns(ctx, resolver, test.host, test.recordType)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(answers, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, answers)
+			}
+		})
+	}
+}
+
+func TestLookupDnsNotFound(t *testing.T) {
+	resolver := NewDnsResolver(startTestDnsServer(t))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := LookupDns(ctx, resolver, "missing.watchdog.test.", enums.ARecord)
+	var dnsError *net.DNSError
+	if !errors.As(err, &dnsError) || !dnsError.IsNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestLookupDnsTimeout(t *testing.T) {
+	resolver := NewDnsResolver(startTestDnsServer(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := LookupDns(ctx, resolver, "silent.watchdog.test.", enums.ARecord)
+	var dnsError *net.DNSError
+	if !errors.As(err, &dnsError) || !dnsError.IsTimeout {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestCheckDns(t *testing.T) {
+	address := startTestDnsServer(t)
+	worker := &ChildWorker{
+		Ctx:          context.Background(),
+		ParentWorker: &ParentWorker{RequestTimeout: 200 * time.Millisecond},
+	}
+
+	tests := []struct {
+		name            string
+		host            string
+		expectedAnswers []string
+		healthy         bool
+		failureCode     enums.FailureCode
+	}{
+		{"matching answers", "dns://www.watchdog.test.", []string{"192.0.2.10", "192.0.2.1"}, true, ""},
+		{"any answer without expectations", "www.watchdog.test.", nil, true, ""},
+		{"changed answers", "www.watchdog.test.", []string{"192.0.2.1"}, false, enums.AssertionFailed},
+		{"nxdomain", "missing.watchdog.test.", nil, false, enums.DnsResolutionFailed},
+		{"no answer", "silent.watchdog.test.", nil, false, enums.DnsResolutionFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task, err := worker.checkDns(database.Url{
+				Url: test.host,
+				Options: core.MonitorOptions{
+					RecordType:      enums.ARecord,
+					Resolver:        address,
+					ExpectedAnswers: test.expectedAnswers,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.Healthy != test.healthy || task.FailureCode != test.failureCode {
+				t.Errorf("expected healthy %v with %q, got %v with %q: %s", test.healthy, test.failureCode, task.Healthy, task.FailureCode, task.FailureReason)
+			}
+		})
+	}
+}
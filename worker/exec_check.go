@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Exit codes of Nagios compatible plugins
+const (
+	pluginOk       = 0
+	pluginWarning  = 1
+	pluginCritical = 2
+)
+
+// maxPluginOutputSize matches the output Nagios keeps of a plugin, anything after it is dropped
+const maxPluginOutputSize = 8192
+
+func (cw *ChildWorker) checkExec(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	command := exec.CommandContext(ctx, url.Url, url.Options.Args...)
+	command.Env = os.Environ()
+	for key, value := range url.Options.Env {
+		command.Env = append(command.Env, key+"="+value)
+	}
+	//plugins that leave children holding stdout open must not block the worker
+	command.WaitDelay = time.Second
+
+	stdout := &limitedBuffer{limit: maxPluginOutputSize}
+	command.Stdout = stdout
+
+	startedAt := time.Now()
+	err := command.Run()
+	task.Latency = time.Since(startedAt)
+	task.Output, task.PerfData = parsePluginOutput(stdout.buffer.Bytes())
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		task.FailureCode = enums.TimedOut
+		task.FailureReason = fmt.Sprintf("UNKNOWN: plugin timed out after %v", timeout)
+		return task, nil
+	}
+
+	exitCode := pluginOk
+	if err != nil {
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) {
+			fmt.Printf("exec error: %v", err)
+			task.FailureCode = enums.CheckFailed
+			task.FailureReason = fmt.Sprintf("UNKNOWN: unable to run plugin: %v", err)
+			return task, nil
+		}
+		exitCode = exitError.ExitCode()
+	}
+
+	switch exitCode {
+	case pluginOk:
+		task.Healthy = true
+	case pluginWarning:
+		task.Healthy = true
+		task.Degraded = true
+		task.DegradedReason = "WARNING"
+	case pluginCritical:
+		//the plugin reached the service and judged it broken
+		task.FailureCode = enums.AssertionFailed
+		task.FailureReason = "CRITICAL"
+	default:
+		//3 is UNKNOWN, anything else is treated the same way as Nagios does
+		task.FailureCode = enums.UnknownResult
+		task.FailureReason = fmt.Sprintf("UNKNOWN: plugin exited with code %d", exitCode)
+	}
+	if task.FailureReason != "" && task.Output != "" {
+		task.FailureReason = fmt.Sprintf("%s: %s", task.FailureReason, task.Output)
+	}
+	return task, nil
+}
+
+// parsePluginOutput splits the first line of a plugin's stdout into its text and the perfdata after the pipe.
+func parsePluginOutput(stdout []byte) (string, string) {
+	scanner := bufio.NewScanner(bytes.NewReader(stdout))
+	if !scanner.Scan() {
+		return "", ""
+	}
+	output, perfData, _ := strings.Cut(scanner.Text(), "|")
+	return strings.TrimSpace(output), strings.TrimSpace(perfData)
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest, so a chatty plugin
+// neither fills the worker's memory nor fails with a broken pipe.
+type limitedBuffer struct {
+	buffer bytes.Buffer
+	limit  int
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := lb.limit - lb.buffer.Len(); remaining > 0 {
+		lb.buffer.Write(p[:min(len(p), remaining)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"github.com/horlerdipo/watchdog/enums"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// assertionError reports a target that answered, but not with what the monitor expects
+type assertionError struct {
+	message string
+}
+
+func (e assertionError) Error() string {
+	return e.message
+}
+
+// statusError reports a server that answered with a status or reply the check does not accept
+type statusError struct {
+	message string
+}
+
+func (e statusError) Error() string {
+	return e.message
+}
+
+// classifyError maps the error a check ran into to the failure code stored with the check.
+func classifyError(err error) enums.FailureCode {
+	var assertion assertionError
+	var status statusError
+	var dnsError *net.DNSError
+	var netError net.Error
+	var protocolError *textproto.Error
+	var certificateError *tls.CertificateVerificationError
+	var recordHeaderError tls.RecordHeaderError
+	var alertError tls.AlertError
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+	var invalidCertificateError x509.CertificateInvalidError
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &assertion):
+		return enums.AssertionFailed
+	case errors.As(err, &status):
+		return enums.UnexpectedStatus
+	case errors.As(err, &dnsError):
+		return enums.DnsResolutionFailed
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return enums.ConnectionRefused
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded), errors.As(err, &netError) && netError.Timeout():
+		return enums.TimedOut
+	case errors.As(err, &certificateError), errors.As(err, &recordHeaderError), errors.As(err, &alertError),
+		errors.As(err, &unknownAuthorityError), errors.As(err, &hostnameError), errors.As(err, &invalidCertificateError):
+		return enums.TlsHandshakeFailed
+	case errors.As(err, &protocolError):
+		return enums.UnexpectedStatus
+	case errors.As(err, &netError), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		return enums.ConnectionFailed
+	}
+
+	//some clients, e.g. gRPC, only keep the message of the underlying error
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no such host"):
+		return enums.DnsResolutionFailed
+	case strings.Contains(message, "connection refused"):
+		return enums.ConnectionRefused
+	case strings.Contains(message, "deadline exceeded"), strings.Contains(message, "timeout"):
+		return enums.TimedOut
+	case strings.Contains(message, "tls:"), strings.Contains(message, "x509:"):
+		return enums.TlsHandshakeFailed
+	}
+	return enums.CheckFailed
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"strings"
+	"time"
+)
+
+func (cw *ChildWorker) checkGrpc(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if url.Options.UseTLS || url.TLS.Enabled() {
+		tlsConfig, err := cw.tlsConfig(url)
+		if err != nil {
+			fmt.Printf("tls error: %v", err)
+			task.FailureCode = enums.CheckFailed
+			task.FailureReason = fmt.Sprintf("unable to load tls configuration: %v", err)
+			return task, nil
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(GrpcAddress(url.Url), grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cw.Ctx, timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: url.Options.GrpcService,
+	})
+	task.Latency = time.Since(startedAt)
+	if err != nil {
+		fmt.Printf("grpc error: %v", err)
+		task.FailureCode = classifyError(err)
+		task.FailureReason = fmt.Sprintf("health check failed: %v", err)
+		return task, nil
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		task.FailureCode = enums.UnexpectedStatus
+		task.FailureReason = fmt.Sprintf("service status is %s", resp.GetStatus())
+		return task, nil
+	}
+
+	fmt.Printf("Worker %d with parent %v interval tried monitoring %v over grpc \n", cw.Id, cw.ParentWorker.Interval, url.Url)
+	task.Healthy = true
+	return task, nil
+}
+
+// GrpcAddress strips an optional grpc:// scheme from a monitor's target.
+func GrpcAddress(target string) string {
+	return strings.TrimPrefix(target, "grpc://")
+}
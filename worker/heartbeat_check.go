@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"time"
+)
+
+func (cw *ChildWorker) checkHeartbeat(url database.Url) (supervisor.Task, error) {
+	heartbeat, err := core.FetchHeartbeat(cw.Ctx, cw.ParentWorker.RedisClient, url.Id)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	deadline := time.Duration(url.MonitoringFrequency.ToSeconds()+url.Options.GraceSeconds) * time.Second
+	task := supervisor.Task{
+		UrlId:   url.Id,
+		Url:     url.Url,
+		Latency: heartbeat.LastDuration,
+	}
+
+	lastSeen := heartbeat.LastPingAt
+	if lastSeen.IsZero() {
+		//a new monitor gets one full period before it is expected to check in
+		if time.Since(url.CreatedAt) < deadline {
+			return supervisor.Task{}, fmt.Errorf("heartbeat monitor %v has not checked in yet", url.Id)
+		}
+		task.FailureCode = enums.TimedOut
+		task.FailureReason = fmt.Sprintf("no heartbeat received since the monitor was created %v ago", time.Since(url.CreatedAt).Round(time.Second))
+		return task, nil
+	}
+
+	if time.Since(lastSeen) > deadline {
+		task.FailureCode = enums.TimedOut
+		task.FailureReason = fmt.Sprintf("no heartbeat received in %v, last one was %v ago", deadline, time.Since(lastSeen).Round(time.Second))
+		return task, nil
+	}
+
+	if heartbeat.Failed {
+		task.FailureCode = enums.CheckFailed
+		task.FailureReason = fmt.Sprintf("job reported a failure %v ago", time.Since(lastSeen).Round(time.Second))
+		return task, nil
+	}
+
+	task.Healthy = true
+	return task, nil
+}
@@ -0,0 +1,293 @@
+package worker
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/env"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultMaxRedirects matches the limit of Go's default http client
+const defaultMaxRedirects = 10
+
+var errTooManyRedirects = errors.New("too many redirects")
+
+// redirectTrace records the hops a request went through
+type redirectTrace struct {
+	chain    []string
+	location string
+}
+
+func (cw *ChildWorker) checkHttp(url database.Url) (supervisor.Task, error) {
+	transport, err := cw.httpTransport(url)
+	if err != nil {
+		fmt.Printf("transport error: %v", err)
+		task := supervisor.Task{
+			Healthy:       false,
+			Url:           url.Url,
+			UrlId:         url.Id,
+			FailureCode:   enums.CheckFailed,
+			FailureReason: fmt.Sprintf("unable to configure connection: %v", err),
+		}
+		return task, nil
+	}
+
+	trace := &redirectTrace{}
+	client := &http.Client{
+		Timeout:       cw.ParentWorker.RequestTimeout,
+		Transport:     transport,
+		CheckRedirect: checkRedirect(url.Options, trace),
+	}
+
+	statusCodeRules, err := core.ParseStatusCodeRules(url.ExpectedStatusCodes)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	request, err := buildRequest(url)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	err = cw.authenticate(request, url.Auth)
+	if err != nil {
+		fmt.Printf("auth error: %v", err)
+		task := supervisor.Task{
+			Healthy:       false,
+			Url:           url.Url,
+			UrlId:         url.Id,
+			FailureCode:   enums.AuthFailed,
+			FailureReason: fmt.Sprintf("unable to fetch auth token: %v", err),
+		}
+		return task, nil
+	}
+
+	trace.chain = []string{request.URL.String()}
+	startedAt := time.Now()
+	resp, err := client.Do(request)
+	latency := time.Since(startedAt)
+	if err != nil {
+		fmt.Printf("client error: %v", err)
+		task := supervisor.Task{
+			Healthy:       false,
+			Url:           url.Url,
+			UrlId:         url.Id,
+			Latency:       latency,
+			FailureCode:   classifyError(err),
+			FailureReason: err.Error(),
+		}
+		if errors.Is(err, errTooManyRedirects) {
+			task.FailureCode = enums.UnexpectedStatus
+			task.FailureReason = fmt.Sprintf("stopped after %d redirects", len(trace.chain)-1)
+			task.Redirects = trace.chain
+		} else if isProxyError(err) {
+			task.FailureCode = enums.ProxyFailed
+			task.FailureReason = fmt.Sprintf("proxy connection failed: %v", err)
+		}
+		return task, nil
+	}
+	defer resp.Body.Close()
+	fmt.Printf("Worker %d with parent %v interval tried monitoring %v and returned %v \n", cw.Id, cw.ParentWorker.Interval, url, resp.StatusCode)
+	task := supervisor.Task{
+		UrlId:   url.Id,
+		Healthy: statusCodeRules.Matches(resp.StatusCode),
+		Url:     url.Url,
+		Latency: latency,
+	}
+	if !task.Healthy {
+		task.FailureCode = enums.UnexpectedStatus
+		task.FailureReason = fmt.Sprintf("unexpected status code %d, expected %s", resp.StatusCode, url.ExpectedStatusCodes)
+	}
+
+	//plain http requests are forwarded by the proxy, so a rejected proxy login arrives as a response
+	if resp.StatusCode == http.StatusProxyAuthRequired && proxySetting(url) != "" {
+		task.Healthy = false
+		task.FailureCode = enums.ProxyFailed
+		task.FailureReason = "proxy connection failed: proxy authentication required"
+		return task, nil
+	}
+
+	//a redirect that was not followed still belongs in the chain
+	if location := resp.Header.Get("Location"); location != "" {
+		trace.location = location
+		if target, err := resp.Request.URL.Parse(location); err == nil {
+			trace.chain = append(trace.chain, target.String())
+		}
+	}
+	if len(trace.chain) > 1 {
+		task.Redirects = trace.chain
+	}
+
+	if task.Healthy {
+		task.FailedAssertion = checkRedirectAssertions(url.Options, resp.Request.URL.String(), trace.location)
+		task.Healthy = task.FailedAssertion == ""
+	}
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		task.CertificateExpiresAt = resp.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if task.Healthy && (len(url.Assertions) > 0 || url.Options.DetectContentChanges) {
+		maxBodySize := int64(env.FetchInt("HTTP_MAX_BODY_SIZE", 1048576))
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			fmt.Printf("client error: %v", err)
+			task.Healthy = false
+			task.FailureCode = classifyError(err)
+			task.FailedAssertion = fmt.Sprintf("unable to read response body: %v", err)
+		} else {
+			failedAssertion, err := core.EvaluateAssertions(url.Assertions, body)
+			if failedAssertion != nil {
+				task.Healthy = false
+				task.FailedAssertion = failedAssertion.String()
+				if err != nil {
+					task.FailedAssertion = fmt.Sprintf("%s: %v", task.FailedAssertion, err)
+				}
+			}
+		}
+
+		//error pages are not content, only healthy responses are compared with the baseline
+		if task.Healthy && url.Options.DetectContentChanges {
+			task.ContentHash, err = core.HashContent(body, url.Options.ContentExclusions)
+			if err != nil {
+				fmt.Printf("content hash error: %v", err)
+			}
+		}
+	}
+
+	return task, nil
+}
+
+// tlsConfig returns the monitor's cached tls.Config, or nil when it uses the defaults.
+func (cw *ChildWorker) tlsConfig(url database.Url) (*tls.Config, error) {
+	if !url.TLS.Enabled() {
+		return nil, nil
+	}
+	return cw.ParentWorker.TLSCache.Config(url.TLS)
+}
+
+// httpTransport returns the transport HTTP checks of url are sent through.
+func (cw *ChildWorker) httpTransport(url database.Url) (http.RoundTripper, error) {
+	tlsConfig, err := cw.tlsConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := proxyFunc(url)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil && proxySetting(url) == "" {
+		return http.DefaultTransport, nil
+	}
+
+	//the transport only lives for one check, so its connections must not be kept around
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy
+	transport.DisableKeepAlives = true
+	return transport, nil
+}
+
+// checkRedirect applies the monitor's redirect policy and records every hop in trace.
+func checkRedirect(options core.MonitorOptions, trace *redirectTrace) func(*http.Request, []*http.Request) error {
+	maxRedirects := options.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	return func(request *http.Request, via []*http.Request) error {
+		if options.RedirectPolicy == enums.NoFollowRedirects {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > maxRedirects {
+			return errTooManyRedirects
+		}
+		trace.chain = append(trace.chain, request.URL.String())
+		if request.Response != nil {
+			trace.location = request.Response.Header.Get("Location")
+		}
+		return nil
+	}
+}
+
+// checkRedirectAssertions returns a description of the first failing final url or Location assertion.
+func checkRedirectAssertions(options core.MonitorOptions, finalUrl string, location string) string {
+	if options.ExpectedFinalUrl != "" {
+		matched, err := regexp.MatchString(options.ExpectedFinalUrl, finalUrl)
+		if err != nil || !matched {
+			return fmt.Sprintf("final url %s does not match %s", finalUrl, options.ExpectedFinalUrl)
+		}
+	}
+	if options.ExpectedLocation != "" {
+		matched, err := regexp.MatchString(options.ExpectedLocation, location)
+		if err != nil || !matched {
+			return fmt.Sprintf("location header %q does not match %s", location, options.ExpectedLocation)
+		}
+	}
+	return ""
+}
+
+func (cw *ChildWorker) authenticate(request *http.Request, auth core.AuthConfig) error {
+	switch auth.Type {
+	case enums.BasicAuth:
+		request.SetBasicAuth(auth.Username, auth.Password)
+	case enums.BearerAuth:
+		request.Header.Set("Authorization", "Bearer "+auth.Token)
+	case enums.OAuth2:
+		token, err := cw.ParentWorker.TokenCache.Token(cw.Ctx, auth)
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+func buildRequest(url database.Url) (*http.Request, error) {
+	target, err := neturl.Parse(url.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(url.QueryParams) > 0 {
+		query := target.Query()
+		for key, value := range url.QueryParams {
+			query.Set(key, value)
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	var body io.Reader
+	if url.RequestBody != "" {
+		body = strings.NewReader(url.RequestBody)
+	}
+
+	request, err := http.NewRequest(url.HttpMethod.ToMethod(), target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if url.ContentType != "" {
+		request.Header.Set("Content-Type", url.ContentType)
+	}
+
+	for key, value := range url.Headers {
+		//net/http ignores the Host header, it has to be set on the request itself
+		if strings.EqualFold(key, "Host") {
+			request.Host = value
+			continue
+		}
+		request.Header.Set(key, value)
+	}
+	return request, nil
+}
@@ -0,0 +1,314 @@
+package worker
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// mailSession is an open connection to a mail server, upgraded in place by STARTTLS.
+type mailSession struct {
+	conn net.Conn
+	text *textproto.Conn
+}
+
+func (ms *mailSession) upgrade(config *tls.Config) (*tls.Conn, error) {
+	tlsConn := tls.Client(ms.conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	ms.conn = tlsConn
+	ms.text = textproto.NewConn(tlsConn)
+	return tlsConn, nil
+}
+
+// mailFailure records which stage of a mail check failed.
+type mailFailure struct {
+	stage string
+	err   error
+}
+
+func (cw *ChildWorker) checkMail(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	address := MailAddress(url.Type, url.Url, url.Options.UseTLS)
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	tlsConfig, err := cw.tlsConfig(url)
+	if err != nil {
+		fmt.Printf("tls error: %v", err)
+		task.FailedStep = "connect"
+		task.FailureCode = enums.CheckFailed
+		task.FailureReason = fmt.Sprintf("unable to load tls configuration: %v", err)
+		return task, nil
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ServerName = host
+
+	startedAt := time.Now()
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		task.Latency = time.Since(startedAt)
+		return mailTaskFailure(task, mailFailure{"connect", err}), nil
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(startedAt.Add(timeout))
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	session := &mailSession{conn: conn, text: textproto.NewConn(conn)}
+	if url.Options.UseTLS {
+		tlsConn, err := session.upgrade(tlsConfig)
+		if err != nil {
+			task.Latency = time.Since(startedAt)
+			return mailTaskFailure(task, mailFailure{"tls", err}), nil
+		}
+		task.CertificateExpiresAt = certificateExpiry(tlsConn)
+	}
+
+	var failure *mailFailure
+	switch url.Type {
+	case enums.Smtp:
+		failure = checkSmtp(session, url, tlsConfig, &task)
+	case enums.Imap:
+		failure = checkImap(session, url, tlsConfig, &task)
+	case enums.Pop3:
+		failure = checkPop3(session, url, tlsConfig, &task)
+	}
+	task.Latency = time.Since(startedAt)
+	if failure != nil {
+		return mailTaskFailure(task, *failure), nil
+	}
+
+	task.Healthy = true
+	return task, nil
+}
+
+func checkSmtp(session *mailSession, url database.Url, tlsConfig *tls.Config, task *supervisor.Task) *mailFailure {
+	_, banner, err := session.text.ReadResponse(220)
+	if err != nil {
+		return &mailFailure{"banner", err}
+	}
+	if failure := checkBanner(url, banner); failure != nil {
+		return failure
+	}
+
+	extensions, err := smtpHello(session)
+	if err != nil {
+		return &mailFailure{"banner", fmt.Errorf("EHLO rejected: %w", err)}
+	}
+
+	if url.Options.StartTLS {
+		if !strings.Contains(extensions, "STARTTLS") {
+			return &mailFailure{"starttls", assertionError{"server does not advertise STARTTLS"}}
+		}
+		if _, _, err := smtpCommand(session, 220, "STARTTLS"); err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		tlsConn, err := session.upgrade(tlsConfig)
+		if err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		task.CertificateExpiresAt = certificateExpiry(tlsConn)
+		if extensions, err = smtpHello(session); err != nil {
+			return &mailFailure{"starttls", fmt.Errorf("EHLO after STARTTLS rejected: %w", err)}
+		}
+	}
+
+	if url.Auth.Type == enums.BasicAuth {
+		credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + url.Auth.Username + "\x00" + url.Auth.Password))
+		if _, _, err := smtpCommand(session, 235, "AUTH PLAIN %s", credentials); err != nil {
+			return &mailFailure{"auth", err}
+		}
+	}
+
+	_, _, _ = smtpCommand(session, 221, "QUIT")
+	return nil
+}
+
+func checkImap(session *mailSession, url database.Url, tlsConfig *tls.Config, task *supervisor.Task) *mailFailure {
+	banner, err := session.text.ReadLine()
+	if err != nil {
+		return &mailFailure{"banner", err}
+	}
+	if !strings.HasPrefix(banner, "* OK") && !strings.HasPrefix(banner, "* PREAUTH") {
+		return &mailFailure{"banner", statusError{fmt.Sprintf("unexpected greeting %q", banner)}}
+	}
+	if failure := checkBanner(url, banner); failure != nil {
+		return failure
+	}
+
+	if url.Options.StartTLS {
+		if err := imapCommand(session, "a1", "STARTTLS"); err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		tlsConn, err := session.upgrade(tlsConfig)
+		if err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		task.CertificateExpiresAt = certificateExpiry(tlsConn)
+	}
+
+	if url.Auth.Type == enums.BasicAuth {
+		if err := imapCommand(session, "a2", "LOGIN %s %s", imapQuote(url.Auth.Username), imapQuote(url.Auth.Password)); err != nil {
+			return &mailFailure{"auth", err}
+		}
+	}
+
+	_ = imapCommand(session, "a3", "LOGOUT")
+	return nil
+}
+
+func checkPop3(session *mailSession, url database.Url, tlsConfig *tls.Config, task *supervisor.Task) *mailFailure {
+	banner, err := session.text.ReadLine()
+	if err != nil {
+		return &mailFailure{"banner", err}
+	}
+	if !strings.HasPrefix(banner, "+OK") {
+		return &mailFailure{"banner", statusError{fmt.Sprintf("unexpected greeting %q", banner)}}
+	}
+	if failure := checkBanner(url, banner); failure != nil {
+		return failure
+	}
+
+	if url.Options.StartTLS {
+		if err := pop3Command(session, "STLS"); err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		tlsConn, err := session.upgrade(tlsConfig)
+		if err != nil {
+			return &mailFailure{"starttls", err}
+		}
+		task.CertificateExpiresAt = certificateExpiry(tlsConn)
+	}
+
+	if url.Auth.Type == enums.BasicAuth {
+		if err := pop3Command(session, "USER %s", url.Auth.Username); err != nil {
+			return &mailFailure{"auth", err}
+		}
+		if err := pop3Command(session, "PASS %s", url.Auth.Password); err != nil {
+			return &mailFailure{"auth", err}
+		}
+	}
+
+	_ = pop3Command(session, "QUIT")
+	return nil
+}
+
+func checkBanner(url database.Url, banner string) *mailFailure {
+	if url.Options.Expect != "" && !strings.Contains(banner, url.Options.Expect) {
+		return &mailFailure{"banner", assertionError{fmt.Sprintf("expected banner containing %q, received %q", url.Options.Expect, banner)}}
+	}
+	return nil
+}
+
+// smtpHello sends EHLO and returns the advertised extensions.
+func smtpHello(session *mailSession) (string, error) {
+	_, extensions, err := smtpCommand(session, 250, "EHLO watchdog")
+	return extensions, err
+}
+
+func smtpCommand(session *mailSession, expectCode int, format string, args ...any) (int, string, error) {
+	id, err := session.text.Cmd(format, args...)
+	if err != nil {
+		return 0, "", err
+	}
+	session.text.StartResponse(id)
+	defer session.text.EndResponse(id)
+	return session.text.ReadResponse(expectCode)
+}
+
+// imapCommand sends a tagged command and waits for its tagged completion, skipping untagged responses.
+func imapCommand(session *mailSession, tag string, format string, args ...any) error {
+	if err := session.text.PrintfLine(tag+" "+format, args...); err != nil {
+		return err
+	}
+	for {
+		line, err := session.text.ReadLine()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, tag+" ") {
+			continue
+		}
+		status := strings.TrimPrefix(line, tag+" ")
+		if !strings.HasPrefix(status, "OK") {
+			return statusError{fmt.Sprintf("server replied %q", status)}
+		}
+		return nil
+	}
+}
+
+func pop3Command(session *mailSession, format string, args ...any) error {
+	if err := session.text.PrintfLine(format, args...); err != nil {
+		return err
+	}
+	line, err := session.text.ReadLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return statusError{fmt.Sprintf("server replied %q", line)}
+	}
+	return nil
+}
+
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func certificateExpiry(conn *tls.Conn) time.Time {
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return time.Time{}
+	}
+	return certificates[0].NotAfter
+}
+
+func mailTaskFailure(task supervisor.Task, failure mailFailure) supervisor.Task {
+	fmt.Printf("%s error: %v", failure.stage, failure.err)
+	task.Healthy = false
+	task.FailedStep = failure.stage
+	task.FailureCode = classifyError(failure.err)
+	task.FailureReason = fmt.Sprintf("%s failed: %v", failure.stage, failure.err)
+	return task
+}
+
+// MailAddress strips an optional smtp://, imap:// or pop3:// scheme and adds the
+// protocol's default port when none is given.
+func MailAddress(monitorType enums.MonitorType, target string, implicitTLS bool) string {
+	target = strings.TrimPrefix(target, string(monitorType)+"://")
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+
+	ports := map[enums.MonitorType][2]string{
+		enums.Smtp: {"25", "465"},
+		enums.Imap: {"143", "993"},
+		enums.Pop3: {"110", "995"},
+	}[monitorType]
+	port := ports[0]
+	if implicitTLS {
+		port = ports[1]
+	}
+	return net.JoinHostPort(target, port)
+}
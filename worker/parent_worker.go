@@ -9,6 +9,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"log"
 	"sync"
+	"time"
 )
 
 type ParentWorker struct {
@@ -19,6 +20,10 @@ type ParentWorker struct {
 	WorkPool                 chan []string
 	ChildWorkerPoolWaitGroup sync.WaitGroup
 	Supervisor               *supervisor.Supervisor
+	TokenCache               *TokenCache
+	TLSCache                 *TLSCache
+	//RequestTimeout bounds every check that has no timeout of its own
+	RequestTimeout time.Duration
 }
 
 func (pw *ParentWorker) Start() {
@@ -81,7 +86,7 @@ func (pw *ParentWorker) spawnChildWorkers(maxChildWorkers int) {
 	}
 }
 
-func NewParentWorker(ctx context.Context, redisClient *redis.Client, interval int, supervisor *supervisor.Supervisor) *ParentWorker {
+func NewParentWorker(ctx context.Context, redisClient *redis.Client, interval int, supervisor *supervisor.Supervisor, tokenCache *TokenCache, tlsCache *TLSCache) *ParentWorker {
 	bufferSize := env.FetchInt("MAXIMUM_WORK_POOL_SIZE")
 	return &ParentWorker{
 		Ctx:                      ctx,
@@ -91,5 +96,8 @@ func NewParentWorker(ctx context.Context, redisClient *redis.Client, interval in
 		WorkPool:                 make(chan []string, bufferSize),
 		ChildWorkerPoolWaitGroup: sync.WaitGroup{},
 		Supervisor:               supervisor,
+		TokenCache:               tokenCache,
+		TLSCache:                 tlsCache,
+		RequestTimeout:           time.Duration(env.FetchInt("HTTP_REQUEST_TIMEOUT", 5)) * time.Second,
 	}
 }
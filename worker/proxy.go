@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"errors"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/env"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+// proxySetting returns the monitor's proxy, falling back to the global MONITOR_PROXY default.
+func proxySetting(url database.Url) string {
+	if url.Proxy != "" {
+		return url.Proxy
+	}
+	return env.FetchString("MONITOR_PROXY", "")
+}
+
+// proxyFunc returns the proxy selection checks of url are made through.
+// Without any setting the standard HTTP_PROXY/HTTPS_PROXY variables apply.
+func proxyFunc(url database.Url) (func(*http.Request) (*neturl.URL, error), error) {
+	setting := proxySetting(url)
+	switch setting {
+	case "":
+		return http.ProxyFromEnvironment, nil
+	case core.DirectProxy:
+		return nil, nil
+	}
+
+	proxyUrl, err := core.ParseProxyUrl(setting)
+	if err != nil {
+		return nil, err
+	}
+	return http.ProxyURL(proxyUrl), nil
+}
+
+// isProxyError reports whether err happened while connecting to or through a proxy.
+func isProxyError(err error) bool {
+	var opError *net.OpError
+	if errors.As(err, &opError) && (opError.Op == "proxyconnect" || strings.HasPrefix(opError.Op, "socks")) {
+		return true
+	}
+	return strings.Contains(err.Error(), "Proxy Authentication Required")
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"net"
+	"strings"
+	"time"
+)
+
+// maxBannerSize caps how much is read from a TCP service while looking for the expected banner.
+const maxBannerSize = 4096
+
+func (cw *ChildWorker) checkTcp(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	startedAt := time.Now()
+	conn, err := net.DialTimeout("tcp", TcpAddress(url.Url), timeout)
+	task.Latency = time.Since(startedAt)
+	if err != nil {
+		fmt.Printf("tcp error: %v", err)
+		task.FailureCode = classifyError(err)
+		task.FailureReason = fmt.Sprintf("unable to connect: %v", err)
+		return task, nil
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(startedAt.Add(timeout))
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	if url.Options.Send != "" {
+		_, err = conn.Write([]byte(url.Options.Send))
+		if err != nil {
+			task.FailureCode = classifyError(err)
+			task.FailureReason = fmt.Sprintf("unable to send payload: %v", err)
+			return task, nil
+		}
+	}
+
+	if url.Options.Expect != "" {
+		banner, err := readUntil(conn, []byte(url.Options.Expect))
+		if err != nil {
+			task.FailureCode = enums.AssertionFailed
+			task.FailureReason = fmt.Sprintf("expected %q, received %q: %v", url.Options.Expect, banner, err)
+			return task, nil
+		}
+	}
+
+	fmt.Printf("Worker %d with parent %v interval tried monitoring %v over tcp \n", cw.Id, cw.ParentWorker.Interval, url.Url)
+	task.Healthy = true
+	return task, nil
+}
+
+// TcpAddress strips an optional tcp:// scheme from a monitor's target.
+func TcpAddress(target string) string {
+	return strings.TrimPrefix(target, "tcp://")
+}
+
+// readUntil reads from conn until expected shows up, the connection closes or the deadline passes.
+func readUntil(conn net.Conn, expected []byte) ([]byte, error) {
+	received := make([]byte, 0, 512)
+	chunk := make([]byte, 512)
+	for len(received) < maxBannerSize {
+		n, err := conn.Read(chunk)
+		received = append(received, chunk[:n]...)
+		if bytes.Contains(received, expected) {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+	}
+	return received, fmt.Errorf("expected payload not found in the first %d bytes", maxBannerSize)
+}
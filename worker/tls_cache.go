@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"crypto/tls"
+	"github.com/horlerdipo/watchdog/core"
+	"os"
+	"sync"
+	"time"
+)
+
+type cachedTLSConfig struct {
+	config     *tls.Config
+	modifiedAt time.Time
+}
+
+// TLSCache builds the tls.Config of each monitor once and shares it between
+// every worker. An entry is rebuilt when one of its files changes on disk.
+type TLSCache struct {
+	mutex   sync.Mutex
+	configs map[string]cachedTLSConfig
+}
+
+func NewTLSCache() *TLSCache {
+	return &TLSCache{
+		configs: make(map[string]cachedTLSConfig),
+	}
+}
+
+func (tc *TLSCache) Config(config core.TLSConfig) (*tls.Config, error) {
+	key := config.CacheKey()
+	modifiedAt := lastModified(config.ClientCertFile, config.ClientKeyFile, config.CaBundleFile)
+
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	cached, ok := tc.configs[key]
+	if ok && cached.modifiedAt.Equal(modifiedAt) {
+		return cached.config, nil
+	}
+
+	loaded, err := config.Load()
+	if err != nil {
+		delete(tc.configs, key)
+		return nil, err
+	}
+	tc.configs[key] = cachedTLSConfig{
+		config:     loaded,
+		modifiedAt: modifiedAt,
+	}
+	return loaded, nil
+}
+
+// lastModified returns the most recent modification time of the given files, ignoring empty paths.
+func lastModified(paths ...string) time.Time {
+	var latest time.Time
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshWindow is how long before expiry a cached token is considered stale.
+const tokenRefreshWindow = 30 * time.Second
+
+type cachedToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// tokenEntry guards the token of one cache key, so a slow token endpoint only
+// holds up the checks that share its credentials.
+type tokenEntry struct {
+	mutex sync.Mutex
+	token cachedToken
+}
+
+// TokenCache fetches OAuth2 client-credentials tokens and shares them between
+// every worker until they are about to expire.
+type TokenCache struct {
+	mutex   sync.Mutex
+	entries map[string]*tokenEntry
+	client  *http.Client
+}
+
+func NewTokenCache(client *http.Client) *TokenCache {
+	return &TokenCache{
+		entries: make(map[string]*tokenEntry),
+		client:  client,
+	}
+}
+
+func (tc *TokenCache) Token(ctx context.Context, auth core.AuthConfig) (string, error) {
+	key := auth.CacheKey()
+
+	tc.mutex.Lock()
+	entry, ok := tc.entries[key]
+	if !ok {
+		entry = &tokenEntry{}
+		tc.entries[key] = entry
+	}
+	tc.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if time.Now().Add(tokenRefreshWindow).Before(entry.token.expiresAt) {
+		return entry.token.accessToken, nil
+	}
+
+	token, err := tc.fetch(ctx, auth)
+	if err != nil {
+		entry.token = cachedToken{}
+		return "", err
+	}
+	entry.token = token
+	return token.accessToken, nil
+}
+
+func (tc *TokenCache) fetch(ctx context.Context, auth core.AuthConfig) (cachedToken, error) {
+	form := neturl.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, auth.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return cachedToken{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(neturl.QueryEscape(auth.ClientId), neturl.QueryEscape(auth.ClientSecret))
+
+	resp, err := tc.client.Do(request)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return cachedToken{}, fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return cachedToken{}, fmt.Errorf("invalid token response: %w", err)
+	}
+	if payload.AccessToken == "" {
+		return cachedToken{}, fmt.Errorf("token response did not include an access_token")
+	}
+
+	//tokens without an expiry are refreshed on every check
+	expiresAt := time.Now()
+	if payload.ExpiresIn > 0 {
+		expiresAt = expiresAt.Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+
+	return cachedToken{
+		accessToken: payload.AccessToken,
+		expiresAt:   expiresAt,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"fmt"
+	"github.com/horlerdipo/watchdog/core"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/env"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	neturl "net/url"
+	"time"
+)
+
+func (cw *ChildWorker) checkTransaction(url database.Url) (supervisor.Task, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	base, err := neturl.Parse(url.Url)
+	if err != nil {
+		return supervisor.Task{}, err
+	}
+
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	transport, err := cw.httpTransport(url)
+	if err != nil {
+		fmt.Printf("transport error: %v", err)
+		task.FailureCode = enums.CheckFailed
+		task.FailureReason = fmt.Sprintf("unable to configure connection: %v", err)
+		return task, nil
+	}
+	client := &http.Client{
+		Timeout:   cw.ParentWorker.RequestTimeout,
+		Transport: transport,
+		Jar:       jar,
+	}
+	variables := make(map[string]string)
+
+	for _, step := range url.Options.Steps {
+		result, failureCode, failureReason := cw.runTransactionStep(client, base, url, step, variables)
+		task.Steps = append(task.Steps, result)
+		task.Latency += time.Duration(result.LatencyMs) * time.Millisecond
+		if failureReason != "" {
+			fmt.Printf("transaction step %v failed: %v", step.Name, failureReason)
+			task.FailedStep = step.Name
+			task.FailureCode = failureCode
+			task.FailureReason = failureReason
+			return task, nil
+		}
+	}
+
+	fmt.Printf("Worker %d with parent %v interval completed %d transaction steps for %v \n", cw.Id, cw.ParentWorker.Interval, len(task.Steps), url.Url)
+	task.Healthy = true
+	return task, nil
+}
+
+// runTransactionStep performs one step, storing its extractions in variables.
+// It returns an empty failure code and reason when the step succeeded.
+func (cw *ChildWorker) runTransactionStep(client *http.Client, base *neturl.URL, url database.Url, step core.TransactionStep, variables map[string]string) (core.StepResult, enums.FailureCode, string) {
+	result := core.StepResult{Name: step.Name}
+
+	target, err := base.Parse(core.SubstituteVariables(step.Url, variables))
+	if err != nil {
+		return result, enums.CheckFailed, fmt.Sprintf("invalid url: %v", err)
+	}
+
+	headers := make(map[string]string, len(step.Headers))
+	for key, value := range step.Headers {
+		headers[key] = core.SubstituteVariables(value, variables)
+	}
+
+	request, err := buildRequest(database.Url{
+		Url:         target.String(),
+		HttpMethod:  step.Method,
+		Headers:     headers,
+		RequestBody: core.SubstituteVariables(step.Body, variables),
+		ContentType: step.ContentType,
+	})
+	if err != nil {
+		return result, enums.CheckFailed, fmt.Sprintf("unable to build request: %v", err)
+	}
+
+	err = cw.authenticate(request, url.Auth)
+	if err != nil {
+		return result, enums.AuthFailed, fmt.Sprintf("unable to fetch auth token: %v", err)
+	}
+
+	startedAt := time.Now()
+	resp, err := client.Do(request)
+	result.LatencyMs = time.Since(startedAt).Milliseconds()
+	if err != nil {
+		if isProxyError(err) {
+			return result, enums.ProxyFailed, fmt.Sprintf("proxy connection failed: %v", err)
+		}
+		return result, classifyError(err), fmt.Sprintf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	maxBodySize := int64(env.FetchInt("HTTP_MAX_BODY_SIZE", 1048576))
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return result, classifyError(err), fmt.Sprintf("unable to read response body: %v", err)
+	}
+
+	statusCodeRules, err := core.ParseStatusCodeRules(step.ExpectedStatusCodes)
+	if err != nil {
+		return result, enums.CheckFailed, err.Error()
+	}
+	if !statusCodeRules.Matches(resp.StatusCode) {
+		return result, enums.UnexpectedStatus, fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+
+	failedAssertion, err := core.EvaluateAssertions(step.Assertions, body)
+	if failedAssertion != nil {
+		if err != nil {
+			return result, enums.AssertionFailed, fmt.Sprintf("assertion failed: %s: %v", failedAssertion, err)
+		}
+		return result, enums.AssertionFailed, fmt.Sprintf("assertion failed: %s", failedAssertion)
+	}
+
+	for _, extraction := range step.Extract {
+		value, err := extract(resp, body, extraction)
+		if err != nil {
+			return result, enums.AssertionFailed, fmt.Sprintf("unable to extract %s: %v", extraction.Variable, err)
+		}
+		variables[extraction.Variable] = value
+	}
+
+	result.Success = true
+	return result, "", ""
+}
+
+func extract(resp *http.Response, body []byte, extraction core.Extraction) (string, error) {
+	switch extraction.Source {
+	case enums.JsonSource:
+		return core.ExtractJsonValue(body, extraction.Path)
+	case enums.HeaderSource:
+		value := resp.Header.Get(extraction.Path)
+		if value == "" {
+			return "", fmt.Errorf("header %s not found", extraction.Path)
+		}
+		return value, nil
+	case enums.CookieSource:
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == extraction.Path {
+				return cookie.Value, nil
+			}
+		}
+		return "", fmt.Errorf("cookie %s not found", extraction.Path)
+	default:
+		return "", fmt.Errorf("invalid extraction source: %s", extraction.Source)
+	}
+}
@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/horlerdipo/watchdog/database"
+	"github.com/horlerdipo/watchdog/enums"
+	"github.com/horlerdipo/watchdog/supervisor"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (cw *ChildWorker) checkWebSocket(url database.Url) (supervisor.Task, error) {
+	timeout := url.Options.Timeout(cw.ParentWorker.RequestTimeout)
+	task := supervisor.Task{
+		UrlId: url.Id,
+		Url:   url.Url,
+	}
+
+	header := http.Header{}
+	for key, value := range url.Headers {
+		header.Set(key, value)
+	}
+
+	tlsConfig, err := cw.tlsConfig(url)
+	if err != nil {
+		fmt.Printf("tls error: %v", err)
+		task.FailedStep = "handshake"
+		task.FailureCode = enums.CheckFailed
+		task.FailureReason = fmt.Sprintf("unable to load tls configuration: %v", err)
+		return task, nil
+	}
+
+	proxy, err := proxyFunc(url)
+	if err != nil {
+		fmt.Printf("proxy error: %v", err)
+		task.FailedStep = "handshake"
+		task.FailureReason = fmt.Sprintf("unable to configure connection: %v", err)
+		return task, nil
+	}
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: timeout,
+		Proxy:            proxy,
+		TLSClientConfig:  tlsConfig,
+	}
+
+	startedAt := time.Now()
+	conn, resp, err := dialer.DialContext(cw.Ctx, url.Url, header)
+	task.Latency = time.Since(startedAt)
+	if err != nil {
+		fmt.Printf("websocket error: %v", err)
+		task.FailedStep = "handshake"
+		task.FailureCode = classifyError(err)
+		task.FailureReason = fmt.Sprintf("handshake failed: %v", err)
+		if isProxyError(err) {
+			task.FailureCode = enums.ProxyFailed
+			task.FailureReason = fmt.Sprintf("proxy connection failed: %v", err)
+		} else if resp != nil {
+			task.FailureCode = enums.UnexpectedStatus
+			task.FailureReason = fmt.Sprintf("handshake failed with status %d: %v", resp.StatusCode, err)
+		}
+		return task, nil
+	}
+	defer conn.Close()
+
+	if resp != nil && resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		task.CertificateExpiresAt = resp.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if url.Options.Send != "" {
+		err = conn.SetWriteDeadline(time.Now().Add(timeout))
+		if err == nil {
+			err = conn.WriteMessage(websocket.TextMessage, []byte(url.Options.Send))
+		}
+		if err != nil {
+			task.FailedStep = "message"
+			task.FailureCode = classifyError(err)
+			task.FailureReason = fmt.Sprintf("unable to send message: %v", err)
+			return task, nil
+		}
+	}
+
+	if url.Options.Expect != "" {
+		err = conn.SetReadDeadline(time.Now().Add(timeout))
+		if err != nil {
+			return supervisor.Task{}, err
+		}
+		_, reply, err := conn.ReadMessage()
+		if err != nil {
+			task.FailedStep = "message"
+			task.FailureCode = classifyError(err)
+			task.FailureReason = fmt.Sprintf("no reply received: %v", err)
+			return task, nil
+		}
+		if !strings.Contains(string(reply), url.Options.Expect) {
+			task.FailedStep = "message"
+			task.FailureCode = enums.AssertionFailed
+			task.FailureReason = fmt.Sprintf("expected reply containing %q, received %q", url.Options.Expect, reply)
+			return task, nil
+		}
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+
+	fmt.Printf("Worker %d with parent %v interval tried monitoring %v over websocket \n", cw.Id, cw.ParentWorker.Interval, url.Url)
+	task.Healthy = true
+	return task, nil
+}